@@ -5,24 +5,84 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
 )
 
 var _ resource.Resource = &AccessTokenResource{}
 var _ resource.ResourceWithImportState = &AccessTokenResource{}
+var _ resource.ResourceWithModifyPlan = &AccessTokenResource{}
+
+// defaultValidFor is the token validity used when neither valid_for nor the
+// provider default_token_ttl is set.
+const defaultValidFor = "1h"
+
+// defaultMeetUrl is the Livekit Meet instance hosted by Livekit.
+const defaultMeetUrl = "https://meet.livekit.io"
+
+// defaultRole is the role of tokens without role. Its grants are the
+// defaults of the grant attributes.
+const defaultRole = "viewer"
+
+// tokenRoles holds the grants each role sets, by attribute. Grants not
+// listed are false.
+var tokenRoles = map[string]map[string]bool{
+	"viewer": {
+		"can_subscribe": true,
+	},
+	"publisher": {
+		"can_publish":      true,
+		"can_publish_data": true,
+		"can_subscribe":    true,
+	},
+	"moderator": {
+		"can_publish":      true,
+		"can_publish_data": true,
+		"can_subscribe":    true,
+		"room_admin":       true,
+		"room_record":      true,
+	},
+	"service": {
+		"can_publish":      true,
+		"can_publish_data": true,
+		"can_subscribe":    true,
+		"room_admin":       true,
+		"room_create":      true,
+		"room_list":        true,
+		"room_record":      true,
+		"hidden":           true,
+	},
+}
+
+// roleGrants are the grant attributes planned from the role when they are
+// not configured.
+var roleGrants = []string{
+	"can_publish", "can_publish_data", "can_subscribe",
+	"room_admin", "room_create", "room_list", "room_record", "hidden",
+}
 
 func NewAccessTokenResource() resource.Resource {
 	return &AccessTokenResource{}
@@ -30,18 +90,176 @@ func NewAccessTokenResource() resource.Resource {
 
 // AccessTokenResource defines the resource implementation.
 type AccessTokenResource struct {
-	apiKeys *auth.AccessToken
+	client *LivekitClient
 }
 
 // AccessTokenResourceModel describes the resource data model.
 type AccessTokenResourceModel struct {
-	Room           types.String `tfsdk:"room"`
-	Identity       types.String `tfsdk:"identity"`
-	CanPublish     types.Bool   `tfsdk:"can_publish"`
-	CanPublishData types.Bool   `tfsdk:"can_publish_data"`
-	CanSubscribe   types.Bool   `tfsdk:"can_subscribe"`
-	ValidFor       types.String `tfsdk:"valid_for"`
-	Token          types.String `tfsdk:"token"`
+	Id                  types.String  `tfsdk:"id"`
+	Room                types.String  `tfsdk:"room"`
+	Rooms               types.Set     `tfsdk:"rooms"`
+	Identity            types.String  `tfsdk:"identity"`
+	RoomJoin            types.Bool    `tfsdk:"room_join"`
+	Role                types.String  `tfsdk:"role"`
+	CanPublish          types.Bool    `tfsdk:"can_publish"`
+	CanPublishData      types.Bool    `tfsdk:"can_publish_data"`
+	CanSubscribe        types.Bool    `tfsdk:"can_subscribe"`
+	CanSubscribeMetrics types.Bool    `tfsdk:"can_subscribe_metrics"`
+	RoomAdmin           types.Bool    `tfsdk:"room_admin"`
+	RoomCreate          types.Bool    `tfsdk:"room_create"`
+	RoomList            types.Bool    `tfsdk:"room_list"`
+	RoomRecord          types.Bool    `tfsdk:"room_record"`
+	CanPublishSources   types.List    `tfsdk:"can_publish_sources"`
+	Hidden              types.Bool    `tfsdk:"hidden"`
+	Recorder            types.Bool    `tfsdk:"recorder"`
+	Agent               types.Bool    `tfsdk:"agent"`
+	IngressAdmin        types.Bool    `tfsdk:"ingress_admin"`
+	Name                types.String  `tfsdk:"name"`
+	Metadata            types.String  `tfsdk:"metadata"`
+	Attributes          types.Map     `tfsdk:"attributes"`
+	Sip                 types.Object  `tfsdk:"sip"`
+	Kind                types.String  `tfsdk:"kind"`
+	RoomConfig          types.Object  `tfsdk:"room_config"`
+	RoomPreset          types.String  `tfsdk:"room_preset"`
+	Sha256              types.String  `tfsdk:"sha256"`
+	NotBefore           types.String  `tfsdk:"not_before"`
+	ValidFor            DurationValue `tfsdk:"valid_for"`
+	TtlSeconds          types.Int64   `tfsdk:"ttl_seconds"`
+	KeyId               types.String  `tfsdk:"key_id"`
+	ApiKey              types.String  `tfsdk:"api_key"`
+	ApiSecret           types.String  `tfsdk:"api_secret"`
+	Endpoint            types.String  `tfsdk:"endpoint"`
+	MinRemaining        types.String  `tfsdk:"min_remaining_validity"`
+	Keepers             types.Map     `tfsdk:"keepers"`
+	ExtraClaims         types.Map     `tfsdk:"extra_claims"`
+	RemoveOnDestroy     types.Bool    `tfsdk:"remove_participant_on_destroy"`
+	Token               types.String  `tfsdk:"token"`
+	RoomTokens          types.Map     `tfsdk:"room_tokens"`
+	TokenSha256         types.String  `tfsdk:"token_sha256"`
+	RoomTokenSha256     types.Map     `tfsdk:"room_token_sha256"`
+	MeetUrl             types.String  `tfsdk:"meet_url"`
+	JoinUrl             types.String  `tfsdk:"join_url"`
+	RoomJoinUrls        types.Map     `tfsdk:"room_join_urls"`
+	ExpiresAt           types.String  `tfsdk:"expires_at"`
+	IssuedAt            types.String  `tfsdk:"issued_at"`
+	Jti                 types.String  `tfsdk:"jti"`
+	Expired             types.Bool    `tfsdk:"expired"`
+	SigningKeyId        types.String  `tfsdk:"signing_key_id"`
+	SignatureValid      types.Bool    `tfsdk:"signature_valid"`
+	Claims              types.Object  `tfsdk:"claims"`
+}
+
+// AccessTokenSipModel describes the sip block of the resource data model.
+type AccessTokenSipModel struct {
+	Admin types.Bool `tfsdk:"admin"`
+	Call  types.Bool `tfsdk:"call"`
+}
+
+var accessTokenSipAttributeTypes = map[string]attr.Type{
+	"admin": types.BoolType,
+	"call":  types.BoolType,
+}
+
+// AccessTokenRoomConfigModel describes the room_config block of the resource
+// data model.
+type AccessTokenRoomConfigModel struct {
+	EmptyTimeout     types.Int64 `tfsdk:"empty_timeout"`
+	DepartureTimeout types.Int64 `tfsdk:"departure_timeout"`
+	MaxParticipants  types.Int64 `tfsdk:"max_participants"`
+	MinPlayoutDelay  types.Int64 `tfsdk:"min_playout_delay"`
+	MaxPlayoutDelay  types.Int64 `tfsdk:"max_playout_delay"`
+	SyncStreams      types.Bool  `tfsdk:"sync_streams"`
+	Agents           types.List  `tfsdk:"agents"`
+}
+
+// AccessTokenRoomAgentModel describes an agent of the room_config block.
+type AccessTokenRoomAgentModel struct {
+	AgentName types.String `tfsdk:"agent_name"`
+	Metadata  types.String `tfsdk:"metadata"`
+}
+
+var accessTokenRoomAgentAttributeTypes = map[string]attr.Type{
+	"agent_name": types.StringType,
+	"metadata":   types.StringType,
+}
+
+var accessTokenRoomConfigAttributeTypes = map[string]attr.Type{
+	"empty_timeout":     types.Int64Type,
+	"departure_timeout": types.Int64Type,
+	"max_participants":  types.Int64Type,
+	"min_playout_delay": types.Int64Type,
+	"max_playout_delay": types.Int64Type,
+	"sync_streams":      types.BoolType,
+	"agents":            types.ListType{ElemType: types.ObjectType{AttrTypes: accessTokenRoomAgentAttributeTypes}},
+}
+
+// AccessTokenClaimsModel describes the decoded payload of the token.
+type AccessTokenClaimsModel struct {
+	Iss        types.String `tfsdk:"iss"`
+	Sub        types.String `tfsdk:"sub"`
+	Jti        types.String `tfsdk:"jti"`
+	Iat        types.Int64  `tfsdk:"iat"`
+	Nbf        types.Int64  `tfsdk:"nbf"`
+	Exp        types.Int64  `tfsdk:"exp"`
+	Name       types.String `tfsdk:"name"`
+	Metadata   types.String `tfsdk:"metadata"`
+	Attributes types.Map    `tfsdk:"attributes"`
+	Kind       types.String `tfsdk:"kind"`
+	RoomPreset types.String `tfsdk:"room_preset"`
+	Video      types.Object `tfsdk:"video"`
+}
+
+// AccessTokenVideoClaimsModel describes the video grant in the decoded
+// payload of the token.
+type AccessTokenVideoClaimsModel struct {
+	Room                types.String `tfsdk:"room"`
+	RoomJoin            types.Bool   `tfsdk:"room_join"`
+	RoomAdmin           types.Bool   `tfsdk:"room_admin"`
+	RoomCreate          types.Bool   `tfsdk:"room_create"`
+	RoomList            types.Bool   `tfsdk:"room_list"`
+	RoomRecord          types.Bool   `tfsdk:"room_record"`
+	CanPublish          types.Bool   `tfsdk:"can_publish"`
+	CanPublishData      types.Bool   `tfsdk:"can_publish_data"`
+	CanSubscribe        types.Bool   `tfsdk:"can_subscribe"`
+	CanSubscribeMetrics types.Bool   `tfsdk:"can_subscribe_metrics"`
+	CanPublishSources   types.List   `tfsdk:"can_publish_sources"`
+	Hidden              types.Bool   `tfsdk:"hidden"`
+	Recorder            types.Bool   `tfsdk:"recorder"`
+	Agent               types.Bool   `tfsdk:"agent"`
+	IngressAdmin        types.Bool   `tfsdk:"ingress_admin"`
+}
+
+var accessTokenVideoClaimsAttributeTypes = map[string]attr.Type{
+	"room":                  types.StringType,
+	"room_join":             types.BoolType,
+	"room_admin":            types.BoolType,
+	"room_create":           types.BoolType,
+	"room_list":             types.BoolType,
+	"room_record":           types.BoolType,
+	"can_publish":           types.BoolType,
+	"can_publish_data":      types.BoolType,
+	"can_subscribe":         types.BoolType,
+	"can_subscribe_metrics": types.BoolType,
+	"can_publish_sources":   types.ListType{ElemType: types.StringType},
+	"hidden":                types.BoolType,
+	"recorder":              types.BoolType,
+	"agent":                 types.BoolType,
+	"ingress_admin":         types.BoolType,
+}
+
+var accessTokenClaimsAttributeTypes = map[string]attr.Type{
+	"iss":         types.StringType,
+	"sub":         types.StringType,
+	"jti":         types.StringType,
+	"iat":         types.Int64Type,
+	"nbf":         types.Int64Type,
+	"exp":         types.Int64Type,
+	"name":        types.StringType,
+	"metadata":    types.StringType,
+	"attributes":  types.MapType{ElemType: types.StringType},
+	"kind":        types.StringType,
+	"room_preset": types.StringType,
+	"video":       types.ObjectType{AttrTypes: accessTokenVideoClaimsAttributeTypes},
 }
 
 func (r *AccessTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -54,50 +272,229 @@ func (r *AccessTokenResource) Schema(ctx context.Context, req resource.SchemaReq
 		MarkdownDescription: "Access Token",
 
 		Attributes: map[string]schema.Attribute{
-			"room": schema.StringAttribute{
-				MarkdownDescription: "Room name",
-				Required:            true,
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, the jti claim of the token",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"room": schema.StringAttribute{
+				MarkdownDescription: "Room name. Conflicts with rooms. Optional when room_join is false",
+				Optional:            true,
+				Validators: []validator.String{
+					roomName(),
+				},
+			},
+			"room_join": schema.BoolAttribute{
+				MarkdownDescription: "Allow joining the room. Set it to false for tokens that only call the server API, e.g. with room_create or room_list",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"rooms": schema.SetAttribute{
+				MarkdownDescription: "Names of several rooms to create tokens for, returned in room_tokens. Livekit tokens grant access to a single room, so this creates one token per room with otherwise identical grants. Conflicts with room",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					roomName(),
 				},
 			},
 			"identity": schema.StringAttribute{
 				MarkdownDescription: "Token identity to connect into the room",
 				Required:            true,
+				Validators: []validator.String{
+					participantIdentity(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"can_publish": schema.BoolAttribute{
-				MarkdownDescription: "Can Publish",
-				Required:            true,
-				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplace(),
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Preset of grants: viewer, publisher, moderator or service. Explicitly set grants take precedence. Defaults to viewer",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf("viewer", "publisher", "moderator", "service"),
 				},
 			},
+			"can_publish": schema.BoolAttribute{
+				MarkdownDescription: "Allow publishing tracks. Defaults to the grant of role",
+				Optional:            true,
+				Computed:            true,
+			},
 			"can_publish_data": schema.BoolAttribute{
-				MarkdownDescription: "Can publish data",
-				Required:            true,
-				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplace(),
-				},
+				MarkdownDescription: "Allow publishing data messages. Defaults to the grant of role",
+				Optional:            true,
+				Computed:            true,
 			},
 			"can_subscribe": schema.BoolAttribute{
-				MarkdownDescription: "Can subscribe",
-				Required:            true,
-				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplace(),
+				MarkdownDescription: "Allow subscribing to tracks. Defaults to the grant of role",
+				Optional:            true,
+				Computed:            true,
+			},
+			"can_subscribe_metrics": schema.BoolAttribute{
+				MarkdownDescription: "Allow subscribing to the metrics of the room, e.g. for observability participants",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"can_publish_sources": schema.ListAttribute{
+				MarkdownDescription: "Restrict publishing to these track sources: camera, microphone, screen_share, screen_share_audio. All sources are allowed when omitted",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listElementsOneOf("camera", "microphone", "screen_share", "screen_share_audio"),
+				},
+			},
+			"room_admin": schema.BoolAttribute{
+				MarkdownDescription: "Allow moderating the room, e.g. muting and removing participants. Defaults to the grant of role",
+				Optional:            true,
+				Computed:            true,
+			},
+			"room_create": schema.BoolAttribute{
+				MarkdownDescription: "Allow creating and deleting rooms. Defaults to the grant of role",
+				Optional:            true,
+				Computed:            true,
+			},
+			"room_list": schema.BoolAttribute{
+				MarkdownDescription: "Allow listing rooms. Defaults to the grant of role",
+				Optional:            true,
+				Computed:            true,
+			},
+			"room_record": schema.BoolAttribute{
+				MarkdownDescription: "Allow starting and stopping recordings through the egress API. Defaults to the grant of role",
+				Optional:            true,
+				Computed:            true,
+			},
+			"hidden": schema.BoolAttribute{
+				MarkdownDescription: "Hide the participant from other participants, e.g. for monitoring bots. Defaults to the grant of role",
+				Optional:            true,
+				Computed:            true,
+			},
+			"recorder": schema.BoolAttribute{
+				MarkdownDescription: "Mark the participant as a recorder, as used by egress and compositor workloads",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"agent": schema.BoolAttribute{
+				MarkdownDescription: "Mark the participant as an agent worker",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"ingress_admin": schema.BoolAttribute{
+				MarkdownDescription: "Allow managing ingress sessions",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the participant, distinct from its identity",
+				Optional:            true,
+			},
+			"metadata": schema.StringAttribute{
+				MarkdownDescription: "Participant metadata, e.g. a JSON document with the role or tenant of the user",
+				Optional:            true,
+			},
+			"attributes": schema.MapAttribute{
+				MarkdownDescription: "Participant attributes, key/value pairs available to all participants of the room",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Participant kind: standard, ingress, egress, sip or agent. Defaults to standard",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf("standard", "ingress", "egress", "sip", "agent"),
+				},
+			},
+			"room_preset": schema.StringAttribute{
+				MarkdownDescription: "Name of a room preset defined in Livekit Cloud, applied when joining with the token creates the room",
+				Optional:            true,
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded SHA-256 digest of a request body the token is bound to, as used to verify webhook payloads",
+				Optional:            true,
+			},
+			"not_before": schema.StringAttribute{
+				MarkdownDescription: "Time from which the token is usable, as an RFC3339 timestamp or a duration offset from creation, e.g. 3d. valid_for counts from this time. Defaults to the creation time",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"valid_for": schema.StringAttribute{
-				MarkdownDescription: "Validity duration of the token, e.g. 1h, 1d, 1w, etc.",
+				MarkdownDescription: "Validity duration of the token, e.g. 1h, 1d, 1w, 6mo or 1y. Defaults to the provider default_token_ttl",
+				CustomType:          DurationType{},
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("1h"),
+				Validators: []validator.String{
+					tokenDuration(),
+				},
+			},
+			"ttl_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Validity of the token in seconds, an alternative to valid_for for computed lifetimes. Conflicts with valid_for and expires_at",
+				Optional:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "API key used to sign the token, one of the keys configured on the provider. Defaults to the provider api_key",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: "API key to sign this token with instead of the provider credentials, e.g. of another Livekit project. Requires api_secret, conflicts with key_id",
+				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"api_secret": schema.StringAttribute{
+				MarkdownDescription: "API secret of api_key",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Livekit server url the token connects to instead of the provider url, for join_url and remove_participant_on_destroy. Calls to it are signed with the key of the token",
+				Optional:            true,
+			},
+			"min_remaining_validity": schema.StringAttribute{
+				MarkdownDescription: "Replace the token during plan once less than this validity is left, e.g. 1d. Ignored when expires_at is set",
+				Optional:            true,
+				Validators: []validator.String{
+					tokenDuration(),
+				},
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that trigger a new token when they change, e.g. a rotation timestamp or an application version",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"extra_claims": schema.MapAttribute{
+				MarkdownDescription: "Additional application specific claims added to the token, e.g. a tenant id. Claims used by Livekit cannot be overridden",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Map{
+					mapKeysNoneOf(reservedClaims...),
+				},
+			},
+			"remove_participant_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Disconnect the participant from the room when the token is destroyed. Requires the provider url or endpoint",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"token": schema.StringAttribute{
 				Computed:            true,
 				Sensitive:           true,
@@ -106,28 +503,546 @@ func (r *AccessTokenResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"room_tokens": schema.MapAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The generated JWT tokens by room name, when rooms is set",
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"token_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Hex encoded SHA-256 digest of token, to reference or compare the token without exposing it",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"room_token_sha256": schema.MapAttribute{
+				Computed:            true,
+				MarkdownDescription: "Digests like token_sha256 of room_tokens by room name, when rooms is set",
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"meet_url": schema.StringAttribute{
+				MarkdownDescription: "Livekit Meet instance that join_url opens. Defaults to https://meet.livekit.io",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultMeetUrl),
+			},
+			"join_url": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Link that joins the room with the token in Livekit Meet, connecting to endpoint or the provider url. Null without either or when rooms is set",
+			},
+			"room_join_urls": schema.MapAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Links like join_url by room name, when rooms is set",
+				ElementType:         types.StringType,
+			},
+			"expires_at": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Expiry time of the token as an RFC3339 timestamp. Set it to pin the expiry to a fixed time instead of valid_for. Conflicts with valid_for and ttl_seconds",
+				Validators: []validator.String{
+					rfc3339Timestamp(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"issued_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Creation time of the token as an RFC3339 timestamp",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expired": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the token has expired. Expired tokens are replaced on the next apply unless expires_at is set",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"claims": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Decoded payload of the token. With rooms, the payload of the token of the first room",
+				Attributes: map[string]schema.Attribute{
+					"iss":         schema.StringAttribute{Computed: true, MarkdownDescription: "API key that signed the token"},
+					"sub":         schema.StringAttribute{Computed: true, MarkdownDescription: "Participant identity"},
+					"jti":         schema.StringAttribute{Computed: true, MarkdownDescription: "Unique identifier of the token"},
+					"iat":         schema.Int64Attribute{Computed: true, MarkdownDescription: "Creation time as Unix timestamp"},
+					"nbf":         schema.Int64Attribute{Computed: true, MarkdownDescription: "Start of the validity as Unix timestamp"},
+					"exp":         schema.Int64Attribute{Computed: true, MarkdownDescription: "Expiry time as Unix timestamp"},
+					"name":        schema.StringAttribute{Computed: true, MarkdownDescription: "Participant display name"},
+					"metadata":    schema.StringAttribute{Computed: true, MarkdownDescription: "Participant metadata"},
+					"attributes":  schema.MapAttribute{Computed: true, ElementType: types.StringType, MarkdownDescription: "Participant attributes"},
+					"kind":        schema.StringAttribute{Computed: true, MarkdownDescription: "Participant kind"},
+					"room_preset": schema.StringAttribute{Computed: true, MarkdownDescription: "Room preset"},
+					"video": schema.SingleNestedAttribute{
+						Computed:            true,
+						MarkdownDescription: "Video grant",
+						Attributes: map[string]schema.Attribute{
+							"room":                  schema.StringAttribute{Computed: true, MarkdownDescription: "Room name, including the provider room_name_prefix"},
+							"room_join":             schema.BoolAttribute{Computed: true},
+							"room_admin":            schema.BoolAttribute{Computed: true},
+							"room_create":           schema.BoolAttribute{Computed: true},
+							"room_list":             schema.BoolAttribute{Computed: true},
+							"room_record":           schema.BoolAttribute{Computed: true},
+							"can_publish":           schema.BoolAttribute{Computed: true},
+							"can_publish_data":      schema.BoolAttribute{Computed: true},
+							"can_subscribe":         schema.BoolAttribute{Computed: true},
+							"can_subscribe_metrics": schema.BoolAttribute{Computed: true},
+							"can_publish_sources":   schema.ListAttribute{Computed: true, ElementType: types.StringType},
+							"hidden":                schema.BoolAttribute{Computed: true},
+							"recorder":              schema.BoolAttribute{Computed: true},
+							"agent":                 schema.BoolAttribute{Computed: true},
+							"ingress_admin":         schema.BoolAttribute{Computed: true},
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"jti": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of the token, its jti claim",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"signing_key_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "API key the token was signed with",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"signature_valid": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the token verifies against the currently configured secret of signing_key_id, as of the last refresh. Tokens that do not, e.g. after a secret rotation, are re-signed on the next apply",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"sip": schema.SingleNestedBlock{
+				MarkdownDescription: "SIP grants of the token",
+				Attributes: map[string]schema.Attribute{
+					"admin": schema.BoolAttribute{
+						MarkdownDescription: "Allow managing SIP trunks and dispatch rules",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+					"call": schema.BoolAttribute{
+						MarkdownDescription: "Allow making outbound SIP calls",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+				},
+			},
+			"room_config": schema.SingleNestedBlock{
+				MarkdownDescription: "Configuration of the room, applied when joining with the token creates it",
+				Attributes: map[string]schema.Attribute{
+					"empty_timeout": schema.Int64Attribute{
+						MarkdownDescription: "Seconds to keep the room open when nobody joins",
+						Optional:            true,
+					},
+					"departure_timeout": schema.Int64Attribute{
+						MarkdownDescription: "Seconds to keep the room open after the last participant left",
+						Optional:            true,
+					},
+					"max_participants": schema.Int64Attribute{
+						MarkdownDescription: "Maximum number of participants in the room",
+						Optional:            true,
+					},
+					"min_playout_delay": schema.Int64Attribute{
+						MarkdownDescription: "Minimum playout delay of subscribed tracks in milliseconds",
+						Optional:            true,
+					},
+					"max_playout_delay": schema.Int64Attribute{
+						MarkdownDescription: "Maximum playout delay of subscribed tracks in milliseconds",
+						Optional:            true,
+					},
+					"sync_streams": schema.BoolAttribute{
+						MarkdownDescription: "Synchronize the audio and video tracks of each participant",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+					"agents": schema.ListNestedAttribute{
+						MarkdownDescription: "Agents dispatched into the room when a participant joins with the token",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"agent_name": schema.StringAttribute{
+									MarkdownDescription: "Name the agent worker registered with",
+									Required:            true,
+								},
+								"metadata": schema.StringAttribute{
+									MarkdownDescription: "Metadata handed to the agent job, e.g. a JSON document with instructions",
+									Optional:            true,
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
-}
+}
+
+func (r *AccessTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*LivekitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *LivekitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AccessTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var room, expiresAt types.String
+	var validFor DurationValue
+	var rooms types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("room"), &room)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rooms"), &rooms)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("valid_for"), &validFor)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expires_at"), &expiresAt)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var keyId, apiKey, apiSecret types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("key_id"), &keyId)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("api_key"), &apiKey)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("api_secret"), &apiSecret)...)
+
+	if apiKey.IsNull() != apiSecret.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("api_key"), "Incomplete signing key",
+			"api_key and api_secret must be set together.")
+		return
+	}
+
+	if !apiKey.IsNull() && !keyId.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("key_id"), "Conflicting signing key",
+			"Only one of key_id and api_key can be set.")
+		return
+	}
+
+	var roomJoin types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("room_join"), &roomJoin)...)
+
+	// Tokens that do not join a room may still be scoped to one, e.g. for
+	// room_admin.
+	if !room.IsNull() && !rooms.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("room"), "Invalid room configuration",
+			"Only one of room and rooms can be set.")
+		return
+	}
+
+	if room.IsNull() && rooms.IsNull() && (roomJoin.IsNull() || roomJoin.ValueBool()) {
+		resp.Diagnostics.AddAttributeError(path.Root("room"), "Invalid room configuration",
+			"One of room and rooms must be set, unless room_join is false.")
+		return
+	}
+
+	var ttlSeconds types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl_seconds"), &ttlSeconds)...)
+
+	expiries := 0
+	for _, value := range []attr.Value{validFor, expiresAt, ttlSeconds} {
+		if !value.IsNull() {
+			expiries++
+		}
+	}
+
+	if expiries > 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Conflicting token expiry",
+			"Only one of valid_for, ttl_seconds and expires_at can be set.")
+		return
+	}
+
+	if !ttlSeconds.IsNull() {
+		validFor = secondsDuration(ttlSeconds)
+	}
+
+	// The default validity comes from the provider configuration, so it cannot
+	// be a static schema default. A token with a fixed expires_at has none.
+	if validFor.IsNull() && expiresAt.IsNull() {
+		validFor = NewDurationValue(defaultTokenTtl(r.client))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("valid_for"), validFor)...)
+
+	planRoleGrants(ctx, req, resp)
+
+	if req.State.Raw.IsNull() {
+		r.planClaims(ctx, req, resp)
+		return
+	}
+
+	var stateValidFor DurationValue
+	var stateExpiresAt types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("valid_for"), &stateValidFor)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("expires_at"), &stateExpiresAt)...)
+
+	equal, diags := validFor.StringSemanticEquals(ctx, stateValidFor)
+	resp.Diagnostics.Append(diags...)
+
+	switch {
+	case !equal:
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("valid_for"))
+	case !validFor.IsUnknown():
+		// Keep the prior spelling, so that e.g. 1h reformatted as 60m plans
+		// no change.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("valid_for"), stateValidFor)...)
+	}
+
+	// Expired tokens are useless, so propose a new one, or already once less
+	// than min_remaining_validity is left. A token pinned to a fixed
+	// expires_at would not live any longer and is left alone.
+	var stateExpired types.Bool
+	var minRemainingValidity types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("expired"), &stateExpired)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("min_remaining_validity"), &minRemainingValidity)...)
+
+	if expiresAt.IsNull() {
+		if stateExpired.ValueBool() {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expired"))
+		} else if renewBefore, err := parseTokenDuration(minRemainingValidity.ValueString()); err == nil && renewBefore > 0 {
+			if expiry, err := time.Parse(time.RFC3339, stateExpiresAt.ValueString()); err == nil && time.Until(expiry) < renewBefore {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("min_remaining_validity"))
+			}
+		}
+	}
+
+	if !expiresAt.IsNull() && !expiresAt.IsUnknown() && !sameTime(expiresAt.ValueString(), stateExpiresAt.ValueString()) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expires_at"))
+	}
+
+	if len(resp.RequiresReplace) > 0 {
+		return
+	}
+
+	// Changed grants are signed into a new token in place, see Update.
+	var plan, state AccessTokenResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	// Tokens that no longer verify against the configured secret, e.g. after
+	// a rotation, are re-signed with it.
+	staleSignature := state.SignatureValid.Equal(types.BoolValue(false))
+
+	if resp.Diagnostics.HasError() || (!grantsChanged(&plan, &state) && !staleSignature) {
+		return
+	}
+
+	if plan.Rooms.IsNull() {
+		plan.Token = types.StringUnknown()
+		plan.TokenSha256 = types.StringUnknown()
+		plan.RoomTokens = types.MapNull(types.StringType)
+		plan.RoomTokenSha256 = types.MapNull(types.StringType)
+		plan.Jti = plan.Id
+	} else {
+		plan.Token = types.StringNull()
+		plan.TokenSha256 = types.StringNull()
+		plan.RoomTokens = types.MapUnknown(types.StringType)
+		plan.RoomTokenSha256 = types.MapUnknown(types.StringType)
+		plan.Jti = types.StringNull()
+	}
+	plan.IssuedAt = types.StringUnknown()
+	plan.Claims = types.ObjectUnknown(accessTokenClaimsAttributeTypes)
+	plan.SignatureValid = types.BoolValue(true)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+
+	r.planClaims(ctx, req, resp)
+}
+
+// planRoleGrants plans the grant attributes that are not configured from the
+// role. They are unknown as long as the role is.
+func planRoleGrants(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var role types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("role"), &role)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grants := tokenRoles[defaultRole]
+	if !role.IsNull() {
+		grants = tokenRoles[role.ValueString()]
+	}
+
+	for _, name := range roleGrants {
+		var configured types.Bool
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &configured)...)
+
+		if !configured.IsNull() {
+			continue
+		}
+
+		planned := types.BoolValue(grants[name])
+		if role.IsUnknown() {
+			planned = types.BoolUnknown()
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), planned)...)
+	}
+}
+
+// grantsChanged reports whether plan changes any claim of the token in state.
+func grantsChanged(plan, state *AccessTokenResourceModel) bool {
+	pairs := [][2]attr.Value{
+		{plan.Room, state.Room},
+		{plan.Rooms, state.Rooms},
+		{plan.RoomJoin, state.RoomJoin},
+		{plan.CanPublish, state.CanPublish},
+		{plan.CanPublishData, state.CanPublishData},
+		{plan.CanSubscribe, state.CanSubscribe},
+		{plan.CanSubscribeMetrics, state.CanSubscribeMetrics},
+		{plan.RoomAdmin, state.RoomAdmin},
+		{plan.RoomCreate, state.RoomCreate},
+		{plan.RoomList, state.RoomList},
+		{plan.RoomRecord, state.RoomRecord},
+		{plan.CanPublishSources, state.CanPublishSources},
+		{plan.Hidden, state.Hidden},
+		{plan.Recorder, state.Recorder},
+		{plan.Agent, state.Agent},
+		{plan.IngressAdmin, state.IngressAdmin},
+		{plan.Name, state.Name},
+		{plan.Metadata, state.Metadata},
+		{plan.Attributes, state.Attributes},
+		{plan.Sip, state.Sip},
+		{plan.Kind, state.Kind},
+		{plan.RoomConfig, state.RoomConfig},
+		{plan.RoomPreset, state.RoomPreset},
+		{plan.Sha256, state.Sha256},
+		{plan.ExtraClaims, state.ExtraClaims},
+	}
+	for _, pair := range pairs {
+		if !pair[0].Equal(pair[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// planClaims fills in the computed attributes of a new or re-signed token that
+// are known before it is signed, so that checks and preconditions can inspect
+// them during plan. Only the signature and the timestamps remain unknown.
+func (r *AccessTokenResource) planClaims(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || resp.Diagnostics.HasError() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data AccessTokenResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &data)...)
+
+	grants := r.claimGrants(ctx, &data, &resp.Diagnostics)
+
+	var extraClaims map[string]string
+	resp.Diagnostics.Append(data.ExtraClaims.ElementsAs(ctx, &extraClaims, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An invalid key is reported when the token is signed.
+	apiKey, _, err := r.signingKey(&data)
+	if err != nil {
+		return
+	}
+
+	// A re-signed token keeps its id.
+	if data.Id.IsUnknown() {
+		data.Id = types.StringValue(uuid.NewString())
+	}
+	registered := jwt.RegisteredClaims{}
+
+	if data.Rooms.IsNull() {
+		data.Jti = data.Id
+		registered.ID = data.Id.ValueString()
+	} else {
+		var rooms []string
+		resp.Diagnostics.Append(data.Rooms.ElementsAs(ctx, &rooms, false)...)
+
+		if resp.Diagnostics.HasError() || len(rooms) == 0 {
+			return
+		}
+
+		roomGrant := *grants.Video
+		roomGrant.Room = r.client.RoomName(slices.Min(rooms))
+		grants.Video = &roomGrant
+		data.Jti = types.StringNull()
+	}
 
-func (r *AccessTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	// Prevent panic if the provider has not been configured.
-	if req.ProviderData == nil {
+	token, err := previewToken(apiKey, grants, r.claimExtensions(ctx, &data, &resp.Diagnostics), registered, extraClaims)
+	if err != nil {
 		return
 	}
 
-	apiKeys, ok := req.ProviderData.(*auth.AccessToken)
+	claims := claimsValue(ctx, token, &resp.Diagnostics).Attributes()
+	claims["iat"] = types.Int64Unknown()
+	claims["nbf"] = types.Int64Unknown()
+	claims["exp"] = types.Int64Unknown()
+	if !data.Rooms.IsNull() {
+		claims["jti"] = types.StringUnknown()
+	}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *auth.AccessToken, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
+	if notBefore, err := time.Parse(time.RFC3339, data.NotBefore.ValueString()); err == nil {
+		claims["nbf"] = types.Int64Value(notBefore.Unix())
+	}
+	if expiresAt, err := time.Parse(time.RFC3339, data.ExpiresAt.ValueString()); err == nil {
+		claims["exp"] = types.Int64Value(expiresAt.Unix())
+	}
 
-		return
+	// A re-signed token keeps the validity of the token it replaces.
+	if !req.State.Raw.IsNull() {
+		var notBefore types.Int64
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("claims").AtName("nbf"), &notBefore)...)
+		claims["nbf"] = notBefore
 	}
 
-	r.apiKeys = apiKeys
+	value, diags := types.ObjectValue(accessTokenClaimsAttributeTypes, claims)
+	resp.Diagnostics.Append(diags...)
+	data.Claims = value
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+// defaultTokenTtl returns the validity of tokens without valid_for. client is
+// nil while the provider is not configured yet.
+func defaultTokenTtl(client *LivekitClient) string {
+	if client != nil && client.DefaultTokenTtl != "" {
+		return client.DefaultTokenTtl
+	}
+	return defaultValidFor
 }
 
 func (r *AccessTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -140,46 +1055,363 @@ func (r *AccessTokenResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	validFor, err := time.ParseDuration(data.ValidFor.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid valid_for ", err.Error())
+	// valid_for is validated during plan, and null when expires_at is set.
+	var validFor time.Duration
+	var err error
+	if !data.ValidFor.IsNull() {
+		validFor, err = parseTokenDuration(data.ValidFor.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("valid_for"), "Invalid valid_for", err.Error())
+			return
+		}
+	}
+
+	grants := r.claimGrants(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	grant := &auth.VideoGrant{
-		Room:           data.Room.ValueString(),
-		CanPublish:     data.CanPublish.ValueBoolPointer(),
-		CanPublishData: data.CanPublishData.ValueBoolPointer(),
-		CanSubscribe:   data.CanSubscribe.ValueBoolPointer(),
-		RoomJoin:       true,
+	// Tokens become usable at not_before and stay valid for valid_for from
+	// then on.
+	issuedAt := time.Now()
+	notBefore := issuedAt
+	if !data.NotBefore.IsNull() {
+		notBefore, err = parseNotBefore(data.NotBefore.ValueString(), issuedAt)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("not_before"), "Invalid not_before", err.Error())
+			return
+		}
+	}
+
+	// A configured expires_at is known at plan time, a computed one is not.
+	expiresAt := notBefore.Add(validFor)
+	if !data.ExpiresAt.IsNull() && !data.ExpiresAt.IsUnknown() {
+		expiresAt, err = time.Parse(time.RFC3339, data.ExpiresAt.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid expires_at", err.Error())
+			return
+		}
+		if !expiresAt.After(issuedAt) {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid expires_at",
+				fmt.Sprintf("The token would already be expired, expires_at %s lies in the past.", formatTime(expiresAt)))
+			return
+		}
+		if !expiresAt.After(notBefore) {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid expires_at",
+				fmt.Sprintf("The token would expire at %s, before it becomes valid at %s.", formatTime(expiresAt), formatTime(notBefore)))
+			return
+		}
 	}
 
-	at := r.apiKeys.AddGrant(grant).
-		SetIdentity(data.Identity.ValueString()).
-		SetValidFor(validFor)
+	if !data.MinRemaining.IsNull() && data.ExpiresAt.IsUnknown() {
+		renewBefore, err := parseTokenDuration(data.MinRemaining.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("min_remaining_validity"), "Invalid min_remaining_validity", err.Error())
+			return
+		}
+		if expiresAt.Sub(issuedAt) <= renewBefore {
+			resp.Diagnostics.AddAttributeError(path.Root("min_remaining_validity"), "Invalid min_remaining_validity",
+				"min_remaining_validity must be shorter than the validity of the token, otherwise it would be replaced on every apply.")
+			return
+		}
+	}
 
-	jwt, err := at.ToJWT()
-	if err != nil {
-		resp.Diagnostics.AddError("Error creating JWT", err.Error())
+	// The id is usually chosen during plan already.
+	if data.Id.IsUnknown() {
+		data.Id = types.StringValue(uuid.NewString())
+	}
+
+	registered := jwt.RegisteredClaims{
+		ID:        data.Id.ValueString(),
+		IssuedAt:  jwt.NewNumericDate(issuedAt),
+		NotBefore: jwt.NewNumericDate(notBefore),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	r.signTokens(ctx, &data, grants, registered, nil, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data.Token = types.StringValue(jwt)
+	if data.ExpiresAt.IsUnknown() {
+		data.ExpiresAt = types.StringValue(formatTime(registered.ExpiresAt.Time))
+	}
+	data.IssuedAt = types.StringValue(formatTime(registered.IssuedAt.Time))
+	data.Expired = types.BoolValue(false)
+	r.setJoinUrls(ctx, &data, &resp.Diagnostics)
 
 	tflog.Trace(ctx, "created a resource")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// signTokens signs the token described by data, or with rooms one token per
+// room, and stores it in data together with its claims. Room tokens take the
+// jti of the token of the same room in previous, if any, so that re-signing
+// keeps their identifiers.
+func (r *AccessTokenResource) signTokens(ctx context.Context, data *AccessTokenResourceModel, grants *auth.ClaimGrants, registered jwt.RegisteredClaims, previous map[string]string, diags *diag.Diagnostics) {
+	apiKey, apiSecret, err := r.signingKey(data)
+	if err != nil {
+		diags.AddAttributeError(path.Root("key_id"), "Invalid key_id", err.Error())
+		return
+	}
+
+	var extraClaims map[string]string
+	diags.Append(data.ExtraClaims.ElementsAs(ctx, &extraClaims, false)...)
+
+	ext := r.claimExtensions(ctx, data, diags)
+
+	if diags.HasError() {
+		return
+	}
+
+	// signed is the token the claims attribute is decoded from.
+	var signed string
+	if data.Rooms.IsNull() {
+		token, err := signToken(apiKey, apiSecret, grants, ext, registered, extraClaims)
+		if err != nil {
+			diags.AddError("Error creating JWT", err.Error())
+			return
+		}
+		signed = token
+
+		data.Token = types.StringValue(token)
+		data.RoomTokens = types.MapNull(types.StringType)
+		data.Jti = data.Id
+	} else {
+		var rooms []string
+		diags.Append(data.Rooms.ElementsAs(ctx, &rooms, false)...)
+
+		if diags.HasError() {
+			return
+		}
+
+		// Every room gets its own token, differing only in the room grant and
+		// the jti.
+		roomTokens := make(map[string]string, len(rooms))
+		for _, room := range rooms {
+			roomGrant := *grants.Video
+			roomGrant.Room = r.client.RoomName(room)
+			roomGrants := *grants
+			roomGrants.Video = &roomGrant
+			registered.ID = uuid.NewString()
+			if token, err := parseToken(previous[room]); err == nil && token.ID != "" {
+				registered.ID = token.ID
+			}
+
+			token, err := signToken(apiKey, apiSecret, &roomGrants, ext, registered, extraClaims)
+			if err != nil {
+				diags.AddError("Error creating JWT", err.Error())
+				return
+			}
+			roomTokens[room] = token
+		}
+
+		tokens, d := types.MapValueFrom(ctx, types.StringType, roomTokens)
+		diags.Append(d...)
+		signed = firstRoomToken(roomTokens)
+		data.Token = types.StringNull()
+		data.RoomTokens = tokens
+		data.Jti = types.StringNull()
+	}
+
+	claims, err := parseToken(signed)
+	if err != nil {
+		diags.AddError("Error parsing token", err.Error())
+		return
+	}
+	data.Claims = claimsValue(ctx, claims, diags)
+	data.SigningKeyId = types.StringValue(apiKey)
+	data.SignatureValid = types.BoolValue(true)
+	setTokenDigests(ctx, data, diags)
+}
+
+// setTokenDigests derives token_sha256 and room_token_sha256 from the tokens
+// in data.
+func setTokenDigests(ctx context.Context, data *AccessTokenResourceModel, diags *diag.Diagnostics) {
+	data.TokenSha256 = types.StringNull()
+	data.RoomTokenSha256 = types.MapNull(types.StringType)
+
+	if !data.Token.IsNull() {
+		data.TokenSha256 = types.StringValue(tokenDigest(data.Token.ValueString()))
+	}
+
+	if !data.RoomTokens.IsNull() {
+		var roomTokens map[string]string
+		diags.Append(data.RoomTokens.ElementsAs(ctx, &roomTokens, false)...)
+
+		digests := make(map[string]string, len(roomTokens))
+		for room, token := range roomTokens {
+			digests[room] = tokenDigest(token)
+		}
+
+		value, d := types.MapValueFrom(ctx, types.StringType, digests)
+		diags.Append(d...)
+		data.RoomTokenSha256 = value
+	}
+}
+
+// claimGrants builds the grants of the token described by data. With rooms,
+// the video grant has no room yet.
+func (r *AccessTokenResource) claimGrants(ctx context.Context, data *AccessTokenResourceModel, diags *diag.Diagnostics) *auth.ClaimGrants {
+	var canPublishSources []string
+	diags.Append(data.CanPublishSources.ElementsAs(ctx, &canPublishSources, false)...)
+
+	if diags.HasError() {
+		return nil
+	}
+
+	grant := &auth.VideoGrant{
+		Room:              r.client.RoomName(data.Room.ValueString()),
+		CanPublish:        data.CanPublish.ValueBoolPointer(),
+		CanPublishData:    data.CanPublishData.ValueBoolPointer(),
+		CanSubscribe:      data.CanSubscribe.ValueBoolPointer(),
+		RoomJoin:          data.RoomJoin.ValueBool(),
+		RoomAdmin:         data.RoomAdmin.ValueBool(),
+		RoomCreate:        data.RoomCreate.ValueBool(),
+		RoomList:          data.RoomList.ValueBool(),
+		RoomRecord:        data.RoomRecord.ValueBool(),
+		CanPublishSources: canPublishSources,
+		Hidden:            data.Hidden.ValueBool(),
+		Recorder:          data.Recorder.ValueBool(),
+		Agent:             data.Agent.ValueBool(),
+		IngressAdmin:      data.IngressAdmin.ValueBool(),
+	}
+
+	grants := &auth.ClaimGrants{
+		Identity: data.Identity.ValueString(),
+		Video:    grant,
+	}
+
+	if !data.Name.IsNull() {
+		grants.Name = data.Name.ValueString()
+	}
+
+	if !data.Metadata.IsNull() {
+		grants.Metadata = data.Metadata.ValueString()
+	}
+
+	if !data.Attributes.IsNull() {
+		diags.Append(data.Attributes.ElementsAs(ctx, &grants.Attributes, false)...)
+
+		if diags.HasError() {
+			return nil
+		}
+	}
+
+	if !data.Sip.IsNull() {
+		var sip AccessTokenSipModel
+		diags.Append(data.Sip.As(ctx, &sip, basetypes.ObjectAsOptions{})...)
+
+		if diags.HasError() {
+			return nil
+		}
+
+		grants.SIP = &auth.SIPGrant{
+			Admin: sip.Admin.ValueBool(),
+			Call:  sip.Call.ValueBool(),
+		}
+	}
+
+	if !data.Sha256.IsNull() {
+		grants.Sha256 = data.Sha256.ValueString()
+	}
+
+	if !data.Kind.IsNull() {
+		kind := livekit.ParticipantInfo_Kind_value[strings.ToUpper(data.Kind.ValueString())]
+		grants.SetParticipantKind(livekit.ParticipantInfo_Kind(kind))
+	}
+
+	return grants
+}
+
+// claimExtensions returns the grants of data that claimGrants cannot
+// express.
+func (r *AccessTokenResource) claimExtensions(ctx context.Context, data *AccessTokenResourceModel, diags *diag.Diagnostics) *grantExtensions {
+	ext := &grantExtensions{
+		CanSubscribeMetrics: data.CanSubscribeMetrics.ValueBool(),
+		RoomPreset:          data.RoomPreset.ValueString(),
+	}
+
+	if data.RoomConfig.IsNull() {
+		return ext
+	}
+
+	var roomConfig AccessTokenRoomConfigModel
+	diags.Append(data.RoomConfig.As(ctx, &roomConfig, basetypes.ObjectAsOptions{})...)
+
+	var agents []AccessTokenRoomAgentModel
+	diags.Append(roomConfig.Agents.ElementsAs(ctx, &agents, false)...)
+
+	if diags.HasError() {
+		return nil
+	}
+
+	ext.RoomConfig = &roomConfiguration{
+		EmptyTimeout:     uint32(roomConfig.EmptyTimeout.ValueInt64()),
+		DepartureTimeout: uint32(roomConfig.DepartureTimeout.ValueInt64()),
+		MaxParticipants:  uint32(roomConfig.MaxParticipants.ValueInt64()),
+		MinPlayoutDelay:  uint32(roomConfig.MinPlayoutDelay.ValueInt64()),
+		MaxPlayoutDelay:  uint32(roomConfig.MaxPlayoutDelay.ValueInt64()),
+		SyncStreams:      roomConfig.SyncStreams.ValueBool(),
+	}
+	for _, agent := range agents {
+		ext.RoomConfig.Agents = append(ext.RoomConfig.Agents, roomAgentDispatch{
+			AgentName: agent.AgentName.ValueString(),
+			Metadata:  agent.Metadata.ValueString(),
+		})
+	}
+	return ext
+}
+
+// signingKey returns the key/secret pair to sign the token described by data.
+func (r *AccessTokenResource) signingKey(data *AccessTokenResourceModel) (string, string, error) {
+	if !data.ApiKey.IsNull() {
+		return data.ApiKey.ValueString(), data.ApiSecret.ValueString(), nil
+	}
+	return r.client.SigningKey(data.KeyId.ValueString())
+}
+
 type LivekitTokenClaims struct {
 	Video struct {
-		Room           string `json:"room"`
-		CanPublish     bool   `json:"canPublish"`
-		CanPublishData bool   `json:"canPublishData"`
-		CanSubscribe   bool   `json:"canSubscribe"`
-		RoomJoin       bool   `json:"roomJoin"`
+		Room                string   `json:"room"`
+		CanPublish          bool     `json:"canPublish"`
+		CanPublishData      bool     `json:"canPublishData"`
+		CanSubscribe        bool     `json:"canSubscribe"`
+		CanSubscribeMetrics bool     `json:"canSubscribeMetrics"`
+		RoomJoin            bool     `json:"roomJoin"`
+		RoomAdmin           bool     `json:"roomAdmin"`
+		RoomCreate          bool     `json:"roomCreate"`
+		RoomList            bool     `json:"roomList"`
+		RoomRecord          bool     `json:"roomRecord"`
+		CanPublishSources   []string `json:"canPublishSources"`
+		Hidden              bool     `json:"hidden"`
+		Recorder            bool     `json:"recorder"`
+		Agent               bool     `json:"agent"`
+		IngressAdmin        bool     `json:"ingressAdmin"`
 	} `json:"video"`
-	jwt.Claims
+	Name       string            `json:"name"`
+	Metadata   string            `json:"metadata"`
+	Attributes map[string]string `json:"attributes"`
+	Sip        *struct {
+		Admin bool `json:"admin"`
+		Call  bool `json:"call"`
+	} `json:"sip"`
+	Kind       string `json:"kind"`
+	RoomConfig *struct {
+		EmptyTimeout     int64               `json:"emptyTimeout"`
+		DepartureTimeout int64               `json:"departureTimeout"`
+		MaxParticipants  int64               `json:"maxParticipants"`
+		MinPlayoutDelay  int64               `json:"minPlayoutDelay"`
+		MaxPlayoutDelay  int64               `json:"maxPlayoutDelay"`
+		SyncStreams      bool                `json:"syncStreams"`
+		Agents           []roomAgentDispatch `json:"agents"`
+	} `json:"roomConfig"`
+	RoomPreset string `json:"roomPreset"`
+	Sha256     string `json:"sha256"`
+	jwt.RegisteredClaims
 }
 
 func (r *AccessTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -192,22 +1424,259 @@ func (r *AccessTokenResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	if !data.Token.IsNull() && !data.Token.IsUnknown() {
-		token, err := parseToken(data.Token.ValueString())
+	jwtToken := data.Token.ValueString()
+	if data.Token.IsNull() && !data.RoomTokens.IsNull() && !data.RoomTokens.IsUnknown() {
+		var roomTokens map[string]string
+		resp.Diagnostics.Append(data.RoomTokens.ElementsAs(ctx, &roomTokens, false)...)
+
+		jwtToken = firstRoomToken(roomTokens)
+	}
+
+	if jwtToken != "" {
+		token, err := parseToken(jwtToken)
 		if err != nil {
 			resp.Diagnostics.AddError("Error parsing token", err.Error())
 			return
 		}
-		data.Room = types.StringValue(token.Video.Room)
+		if data.Rooms.IsNull() && token.Video.Room != "" {
+			data.Room = types.StringValue(r.client.ConfiguredRoomName(token.Video.Room))
+		}
+		data.RoomJoin = types.BoolValue(token.Video.RoomJoin)
+		if token.Subject != "" {
+			data.Identity = types.StringValue(token.Subject)
+		}
 		data.CanPublish = types.BoolValue(token.Video.CanPublish)
 		data.CanPublishData = types.BoolValue(token.Video.CanPublishData)
 		data.CanSubscribe = types.BoolValue(token.Video.CanSubscribe)
+		data.CanSubscribeMetrics = types.BoolValue(token.Video.CanSubscribeMetrics)
+		data.RoomAdmin = types.BoolValue(token.Video.RoomAdmin)
+		data.RoomCreate = types.BoolValue(token.Video.RoomCreate)
+		data.RoomList = types.BoolValue(token.Video.RoomList)
+		data.RoomRecord = types.BoolValue(token.Video.RoomRecord)
+		data.Hidden = types.BoolValue(token.Video.Hidden)
+		data.Recorder = types.BoolValue(token.Video.Recorder)
+		data.Agent = types.BoolValue(token.Video.Agent)
+		data.IngressAdmin = types.BoolValue(token.Video.IngressAdmin)
+		if len(token.Video.CanPublishSources) > 0 {
+			canPublishSources, diags := types.ListValueFrom(ctx, types.StringType, token.Video.CanPublishSources)
+			resp.Diagnostics.Append(diags...)
+			data.CanPublishSources = canPublishSources
+		}
+		if token.Name != "" {
+			data.Name = types.StringValue(token.Name)
+		}
+		if token.Metadata != "" {
+			data.Metadata = types.StringValue(token.Metadata)
+		}
+		if len(token.Attributes) > 0 {
+			attributes, diags := types.MapValueFrom(ctx, types.StringType, token.Attributes)
+			resp.Diagnostics.Append(diags...)
+			data.Attributes = attributes
+		}
+		if token.Sip != nil {
+			sip, diags := types.ObjectValueFrom(ctx, accessTokenSipAttributeTypes, AccessTokenSipModel{
+				Admin: types.BoolValue(token.Sip.Admin),
+				Call:  types.BoolValue(token.Sip.Call),
+			})
+			resp.Diagnostics.Append(diags...)
+			data.Sip = sip
+		}
+		if token.Kind != "" {
+			data.Kind = types.StringValue(token.Kind)
+		}
+		if token.RoomPreset != "" {
+			data.RoomPreset = types.StringValue(token.RoomPreset)
+		}
+		if token.Sha256 != "" {
+			data.Sha256 = types.StringValue(token.Sha256)
+		}
+		if token.RoomConfig != nil {
+			agents := types.ListNull(types.ObjectType{AttrTypes: accessTokenRoomAgentAttributeTypes})
+			if len(token.RoomConfig.Agents) > 0 {
+				models := make([]AccessTokenRoomAgentModel, 0, len(token.RoomConfig.Agents))
+				for _, agent := range token.RoomConfig.Agents {
+					metadata := types.StringNull()
+					if agent.Metadata != "" {
+						metadata = types.StringValue(agent.Metadata)
+					}
+					models = append(models, AccessTokenRoomAgentModel{
+						AgentName: types.StringValue(agent.AgentName),
+						Metadata:  metadata,
+					})
+				}
+				value, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: accessTokenRoomAgentAttributeTypes}, models)
+				resp.Diagnostics.Append(diags...)
+				agents = value
+			}
+			roomConfig, diags := types.ObjectValueFrom(ctx, accessTokenRoomConfigAttributeTypes, AccessTokenRoomConfigModel{
+				EmptyTimeout:     optionalInt64(token.RoomConfig.EmptyTimeout),
+				DepartureTimeout: optionalInt64(token.RoomConfig.DepartureTimeout),
+				MaxParticipants:  optionalInt64(token.RoomConfig.MaxParticipants),
+				MinPlayoutDelay:  optionalInt64(token.RoomConfig.MinPlayoutDelay),
+				MaxPlayoutDelay:  optionalInt64(token.RoomConfig.MaxPlayoutDelay),
+				SyncStreams:      types.BoolValue(token.RoomConfig.SyncStreams),
+				Agents:           agents,
+			})
+			resp.Diagnostics.Append(diags...)
+			data.RoomConfig = roomConfig
+		}
+		// Keep a configured expires_at as written as long as it matches.
+		if token.ExpiresAt != nil && !sameTime(data.ExpiresAt.ValueString(), formatTime(token.ExpiresAt.Time)) {
+			data.ExpiresAt = types.StringValue(formatTime(token.ExpiresAt.Time))
+		}
+		if token.ExpiresAt != nil {
+			data.Expired = types.BoolValue(!token.ExpiresAt.After(time.Now()))
+		}
+		if token.IssuedAt != nil {
+			data.IssuedAt = types.StringValue(formatTime(token.IssuedAt.Time))
+		}
+		data.Claims = claimsValue(ctx, token, &resp.Diagnostics)
+		if token.ID != "" && data.Rooms.IsNull() {
+			data.Jti = types.StringValue(token.ID)
+		}
+
+		signer := r.client.TokenSigner(jwtToken)
+		if !data.ApiSecret.IsNull() && tokenSignedWith(jwtToken, data.ApiSecret.ValueString()) {
+			signer = TokenSignerCurrentSecret
+		}
+		data.SigningKeyId = types.StringValue(token.Issuer)
+		data.SignatureValid = types.BoolValue(signer == TokenSignerCurrentSecret)
+
+		switch signer {
+		case TokenSignerPreviousSecret:
+			resp.Diagnostics.AddWarning("Token signed with previous API secret",
+				fmt.Sprintf("The token for identity %q in room %q was signed with previous_api_secret. "+
+					"It is re-signed with the current secret on the next apply.", data.Identity.ValueString(), data.Room.ValueString()))
+		case TokenSignerUnknown:
+			resp.Diagnostics.AddWarning("Token signed with unknown API secret",
+				fmt.Sprintf("The token for identity %q in room %q was not signed with any of the configured API secrets. "+
+					"It is re-signed with the current secret on the next apply.", data.Identity.ValueString(), data.Room.ValueString()))
+		}
 	}
+	setTokenDigests(ctx, &data, &resp.Diagnostics)
+	r.setJoinUrls(ctx, &data, &resp.Diagnostics)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// setJoinUrls derives join_url and room_join_urls from the tokens in data and
+// the server url.
+func (r *AccessTokenResource) setJoinUrls(ctx context.Context, data *AccessTokenResourceModel, diags *diag.Diagnostics) {
+	data.JoinUrl = types.StringNull()
+	data.RoomJoinUrls = types.MapNull(types.StringType)
+
+	if !data.RoomJoin.ValueBool() {
+		return
+	}
+
+	client, err := r.endpointClient(data)
+	if err != nil {
+		diags.AddAttributeError(clientErrorPath(err), "Cannot create join url", err.Error())
+		return
+	}
+	if client.Url == "" {
+		return
+	}
+
+	meetUrl := data.MeetUrl.ValueString()
+	if data.MeetUrl.IsNull() {
+		meetUrl = defaultMeetUrl
+	}
+
+	if !data.Token.IsNull() {
+		data.JoinUrl = types.StringValue(joinUrl(meetUrl, client.Url, data.Token.ValueString()))
+	}
+
+	if !data.RoomTokens.IsNull() {
+		var roomTokens map[string]string
+		diags.Append(data.RoomTokens.ElementsAs(ctx, &roomTokens, false)...)
+
+		roomJoinUrls := make(map[string]string, len(roomTokens))
+		for room, token := range roomTokens {
+			roomJoinUrls[room] = joinUrl(meetUrl, client.Url, token)
+		}
+
+		value, d := types.MapValueFrom(ctx, types.StringType, roomJoinUrls)
+		diags.Append(d...)
+		data.RoomJoinUrls = value
+	}
+}
+
+// firstRoomToken returns the token of the alphabetically first room. The
+// tokens of all rooms share their claims apart from the room, so any of them
+// will do to refresh the state, as long as it is always the same.
+func firstRoomToken(roomTokens map[string]string) string {
+	firstRoom, firstToken := "", ""
+	for room, token := range roomTokens {
+		if firstToken == "" || room < firstRoom {
+			firstRoom, firstToken = room, token
+		}
+	}
+	return firstToken
+}
+
+// claimsValue builds the claims attribute from the decoded token.
+func claimsValue(ctx context.Context, token *LivekitTokenClaims, diags *diag.Diagnostics) types.Object {
+	numericDate := func(date *jwt.NumericDate) types.Int64 {
+		if date == nil {
+			return types.Int64Null()
+		}
+		return types.Int64Value(date.Unix())
+	}
+
+	canPublishSources, d := types.ListValueFrom(ctx, types.StringType, token.Video.CanPublishSources)
+	diags.Append(d...)
+
+	video, d := types.ObjectValueFrom(ctx, accessTokenVideoClaimsAttributeTypes, AccessTokenVideoClaimsModel{
+		Room:                types.StringValue(token.Video.Room),
+		RoomJoin:            types.BoolValue(token.Video.RoomJoin),
+		RoomAdmin:           types.BoolValue(token.Video.RoomAdmin),
+		RoomCreate:          types.BoolValue(token.Video.RoomCreate),
+		RoomList:            types.BoolValue(token.Video.RoomList),
+		RoomRecord:          types.BoolValue(token.Video.RoomRecord),
+		CanPublish:          types.BoolValue(token.Video.CanPublish),
+		CanPublishData:      types.BoolValue(token.Video.CanPublishData),
+		CanSubscribe:        types.BoolValue(token.Video.CanSubscribe),
+		CanSubscribeMetrics: types.BoolValue(token.Video.CanSubscribeMetrics),
+		CanPublishSources:   canPublishSources,
+		Hidden:              types.BoolValue(token.Video.Hidden),
+		Recorder:            types.BoolValue(token.Video.Recorder),
+		Agent:               types.BoolValue(token.Video.Agent),
+		IngressAdmin:        types.BoolValue(token.Video.IngressAdmin),
+	})
+	diags.Append(d...)
+
+	attributes, d := types.MapValueFrom(ctx, types.StringType, token.Attributes)
+	diags.Append(d...)
+
+	claims, d := types.ObjectValueFrom(ctx, accessTokenClaimsAttributeTypes, AccessTokenClaimsModel{
+		Iss:        types.StringValue(token.Issuer),
+		Sub:        types.StringValue(token.Subject),
+		Jti:        types.StringValue(token.ID),
+		Iat:        numericDate(token.IssuedAt),
+		Nbf:        numericDate(token.NotBefore),
+		Exp:        numericDate(token.ExpiresAt),
+		Name:       types.StringValue(token.Name),
+		Metadata:   types.StringValue(token.Metadata),
+		Attributes: attributes,
+		Kind:       types.StringValue(token.Kind),
+		RoomPreset: types.StringValue(token.RoomPreset),
+		Video:      video,
+	})
+	diags.Append(d...)
+
+	return claims
+}
+
+// optionalInt64 maps the zero value of an omitted claim back to null.
+func optionalInt64(value int64) types.Int64 {
+	if value == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
+
 func parseToken(jwtToken string) (*LivekitTokenClaims, error) {
 	opts := []jwt.ParserOption{
 		jwt.WithStrictDecoding(),
@@ -237,12 +1706,63 @@ func (r *AccessTokenResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	// nothing to do, always requires replacement when field changes.
+	// Only changed grants leave the token unknown during plan, everything
+	// else is stored as planned.
+	if data.Token.IsUnknown() || data.RoomTokens.IsUnknown() {
+		r.resign(ctx, req, &data, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	r.setJoinUrls(ctx, &data, &resp.Diagnostics)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// resign signs the changed grants of data into a new token. The new token
+// keeps the id, jti and validity of the token it replaces, only its issue
+// time changes.
+func (r *AccessTokenResource) resign(ctx context.Context, req resource.UpdateRequest, data *AccessTokenResourceModel, diags *diag.Diagnostics) {
+	var state AccessTokenResourceModel
+	diags.Append(req.State.Get(ctx, &state)...)
+
+	grants := r.claimGrants(ctx, data, diags)
+
+	if diags.HasError() {
+		return
+	}
+
+	var previous map[string]string
+	prior := state.Token.ValueString()
+	if !state.RoomTokens.IsNull() {
+		diags.Append(state.RoomTokens.ElementsAs(ctx, &previous, false)...)
+		prior = firstRoomToken(previous)
+	}
+
+	token, err := parseToken(prior)
+	if err != nil {
+		diags.AddError("Error parsing token", err.Error())
+		return
+	}
+
+	registered := jwt.RegisteredClaims{
+		ID:        data.Id.ValueString(),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		NotBefore: token.NotBefore,
+		ExpiresAt: token.ExpiresAt,
+	}
+
+	r.signTokens(ctx, data, grants, registered, previous, diags)
+
+	if diags.HasError() {
+		return
+	}
+
+	data.IssuedAt = types.StringValue(formatTime(registered.IssuedAt.Time))
+}
+
 func (r *AccessTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data AccessTokenResourceModel
 
@@ -254,8 +1774,99 @@ func (r *AccessTokenResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 
 	// the livekit API does not support deleting tokens, so we don't need to do anything here
+	// unless the participant is to be disconnected.
+	if !data.RemoveOnDestroy.ValueBool() {
+		return
+	}
+
+	client, err := r.endpointClient(&data)
+	if err == nil {
+		err = client.RequireServer()
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot remove participant", err.Error())
+		return
+	}
+
+	var rooms []string
+	if !data.Room.IsNull() {
+		rooms = append(rooms, data.Room.ValueString())
+	}
+	if !data.Rooms.IsNull() {
+		resp.Diagnostics.Append(data.Rooms.ElementsAs(ctx, &rooms, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	for _, room := range rooms {
+		roomName := client.RoomName(room)
+
+		authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomAdmin: true, Room: roomName})
+		if err != nil {
+			resp.Diagnostics.AddError("Error creating API token", err.Error())
+			return
+		}
+
+		_, err = client.RoomService.RemoveParticipant(authCtx, &livekit.RoomParticipantIdentity{
+			Room:     roomName,
+			Identity: data.Identity.ValueString(),
+		})
+		// A participant that is not connected is as good as removed.
+		if err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError("Error removing participant",
+				fmt.Sprintf("Could not remove participant %q from room %q: %s", data.Identity.ValueString(), roomName, err))
+			return
+		}
+	}
+}
+
+// endpointClient returns the client for the endpoint of the token, signing
+// server API calls with the key pair of the token.
+func (r *AccessTokenResource) endpointClient(data *AccessTokenResourceModel) (*LivekitClient, error) {
+	client := r.client
+	if !data.ApiKey.IsNull() {
+		client = client.WithCredentials(data.ApiKey.ValueString(), data.ApiSecret.ValueString())
+	}
+
+	return client.ForEndpoint(data.Endpoint.ValueString(), data.KeyId.ValueString())
 }
 
+// ImportState takes a token signed with one of the provider keys as import
+// ID. Read then restores the attributes from its claims.
 func (r *AccessTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	token, err := parseToken(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("The import ID must be a Livekit access token: %s", err))
+		return
+	}
+
+	if r.client.TokenSigner(req.ID) == TokenSignerUnknown {
+		resp.Diagnostics.AddError("Invalid import ID",
+			"The token was not signed with any of the API secrets configured on the provider.")
+		return
+	}
+
+	// Tokens minted outside of Terraform may lack a jti.
+	id := token.ID
+	if id == "" {
+		id = uuid.NewString()
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("token"), req.ID)...)
+
+	if token.Issuer != r.client.ApiKey {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key_id"), token.Issuer)...)
+	}
+
+	if token.NotBefore != nil && token.ExpiresAt != nil {
+		validFor := token.ExpiresAt.Sub(token.NotBefore.Time)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("valid_for"), NewDurationValue(validFor.String()))...)
+
+		if token.IssuedAt != nil && token.NotBefore.After(token.IssuedAt.Time) {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("not_before"), formatTime(token.NotBefore.Time))...)
+		}
+	}
 }
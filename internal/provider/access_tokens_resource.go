@@ -0,0 +1,532 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/livekit/protocol/auth"
+)
+
+var _ resource.Resource = &AccessTokensResource{}
+var _ resource.ResourceWithModifyPlan = &AccessTokensResource{}
+
+func NewAccessTokensResource() resource.Resource {
+	return &AccessTokensResource{}
+}
+
+// AccessTokensResource defines the resource implementation.
+type AccessTokensResource struct {
+	client *LivekitClient
+}
+
+// AccessTokensResourceModel describes the resource data model.
+type AccessTokensResourceModel struct {
+	Id                types.String  `tfsdk:"id"`
+	Room              types.String  `tfsdk:"room"`
+	Identities        types.Set     `tfsdk:"identities"`
+	Participants      types.Map     `tfsdk:"participants"`
+	CanPublish        types.Bool    `tfsdk:"can_publish"`
+	CanPublishData    types.Bool    `tfsdk:"can_publish_data"`
+	CanSubscribe      types.Bool    `tfsdk:"can_subscribe"`
+	CanPublishSources types.List    `tfsdk:"can_publish_sources"`
+	Hidden            types.Bool    `tfsdk:"hidden"`
+	ValidFor          DurationValue `tfsdk:"valid_for"`
+	TtlSeconds        types.Int64   `tfsdk:"ttl_seconds"`
+	KeyId             types.String  `tfsdk:"key_id"`
+	Keepers           types.Map     `tfsdk:"keepers"`
+	Tokens            types.Map     `tfsdk:"tokens"`
+	IssuedAt          types.String  `tfsdk:"issued_at"`
+	ExpiresAt         types.String  `tfsdk:"expires_at"`
+}
+
+// AccessTokensParticipantModel describes the per identity overrides of the
+// resource data model.
+type AccessTokensParticipantModel struct {
+	Name           types.String `tfsdk:"name"`
+	Metadata       types.String `tfsdk:"metadata"`
+	CanPublish     types.Bool   `tfsdk:"can_publish"`
+	CanPublishData types.Bool   `tfsdk:"can_publish_data"`
+	CanSubscribe   types.Bool   `tfsdk:"can_subscribe"`
+}
+
+func (r *AccessTokensResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_tokens"
+}
+
+func (r *AccessTokensResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Access tokens for many identities of one room",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"room": schema.StringAttribute{
+				MarkdownDescription: "Room name",
+				Required:            true,
+				Validators: []validator.String{
+					roomName(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"identities": schema.SetAttribute{
+				MarkdownDescription: "Identities to create tokens for with the grants of the resource",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					participantIdentity(),
+				},
+			},
+			"participants": schema.MapNestedAttribute{
+				MarkdownDescription: "Identities to create tokens for, with overrides of the grants of the resource",
+				Optional:            true,
+				Validators: []validator.Map{
+					participantIdentity(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Display name of the participant",
+							Optional:            true,
+						},
+						"metadata": schema.StringAttribute{
+							MarkdownDescription: "Participant metadata",
+							Optional:            true,
+						},
+						"can_publish": schema.BoolAttribute{
+							MarkdownDescription: "Allow publishing tracks, overriding can_publish of the resource",
+							Optional:            true,
+						},
+						"can_publish_data": schema.BoolAttribute{
+							MarkdownDescription: "Allow publishing data messages, overriding can_publish_data of the resource",
+							Optional:            true,
+						},
+						"can_subscribe": schema.BoolAttribute{
+							MarkdownDescription: "Allow subscribing to tracks, overriding can_subscribe of the resource",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"can_publish": schema.BoolAttribute{
+				MarkdownDescription: "Allow publishing tracks",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"can_publish_data": schema.BoolAttribute{
+				MarkdownDescription: "Allow publishing data messages",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"can_subscribe": schema.BoolAttribute{
+				MarkdownDescription: "Allow subscribing to tracks",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"can_publish_sources": schema.ListAttribute{
+				MarkdownDescription: "Restrict publishing to these track sources: camera, microphone, screen_share, screen_share_audio. All sources are allowed when omitted",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listElementsOneOf("camera", "microphone", "screen_share", "screen_share_audio"),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"hidden": schema.BoolAttribute{
+				MarkdownDescription: "Hide the participants from other participants",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"valid_for": schema.StringAttribute{
+				MarkdownDescription: "Validity duration of the tokens, e.g. 1h, 1d, 1w, 6mo or 1y. Defaults to the provider default_token_ttl",
+				CustomType:          DurationType{},
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					tokenDuration(),
+				},
+			},
+			"ttl_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Validity of the tokens in seconds, an alternative to valid_for for computed lifetimes. Conflicts with valid_for",
+				Optional:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "API key used to sign the tokens, one of the keys configured on the provider. Defaults to the provider api_key",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that trigger new tokens when they change",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"tokens": schema.MapAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The generated JWT tokens by identity",
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"issued_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Creation time of the most recently signed tokens as an RFC3339 timestamp",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Expiry time of the tokens as an RFC3339 timestamp",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AccessTokensResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*LivekitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *LivekitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AccessTokensResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var identities types.Set
+	var participants types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("identities"), &identities)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("participants"), &participants)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !identities.IsUnknown() && !participants.IsUnknown() && len(identities.Elements()) == 0 && len(participants.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("identities"), "No identities",
+			"Set identities or participants to create tokens for.")
+		return
+	}
+
+	var validFor DurationValue
+	var ttlSeconds types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("valid_for"), &validFor)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl_seconds"), &ttlSeconds)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !validFor.IsNull() && !ttlSeconds.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("ttl_seconds"), "Conflicting token expiry",
+			"Only one of valid_for and ttl_seconds can be set.")
+		return
+	}
+
+	if !ttlSeconds.IsNull() {
+		validFor = secondsDuration(ttlSeconds)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("valid_for"), validFor)...)
+	}
+
+	if validFor.IsNull() {
+		validFor = NewDurationValue(defaultTokenTtl(r.client))
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("valid_for"), validFor)...)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var stateValidFor DurationValue
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("valid_for"), &stateValidFor)...)
+
+	equal, diags := validFor.StringSemanticEquals(ctx, stateValidFor)
+	resp.Diagnostics.Append(diags...)
+
+	switch {
+	case !equal:
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("valid_for"))
+	case !validFor.IsUnknown():
+		// Keep the prior spelling, so that e.g. 1h reformatted as 60m plans
+		// no change.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("valid_for"), stateValidFor)...)
+	}
+
+	// Adding, removing or changing participants updates the affected tokens
+	// in place, so the planned tokens are only known when nothing changed.
+	var planIdentities, stateIdentities types.Set
+	var planParticipants, stateParticipants types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("identities"), &planIdentities)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("identities"), &stateIdentities)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("participants"), &planParticipants)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("participants"), &stateParticipants)...)
+
+	if !planIdentities.Equal(stateIdentities) || !planParticipants.Equal(stateParticipants) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tokens"), types.MapUnknown(types.StringType))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("issued_at"), types.StringUnknown())...)
+	}
+}
+
+func (r *AccessTokensResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AccessTokensResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validFor, err := parseTokenDuration(data.ValidFor.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("valid_for"), "Invalid valid_for", err.Error())
+		return
+	}
+
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(validFor)
+
+	tokens, _ := r.signTokens(ctx, &data, nil, nil, issuedAt, expiresAt, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(uuid.NewString())
+	data.Tokens = tokens
+	data.IssuedAt = types.StringValue(formatTime(issuedAt))
+	data.ExpiresAt = types.StringValue(formatTime(expiresAt))
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccessTokensResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AccessTokensResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The tokens are only known to Terraform, so there is nothing to refresh.
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update signs tokens for new and changed participants and keeps the tokens
+// of all others. New tokens expire together with the existing ones.
+func (r *AccessTokensResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state AccessTokensResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, state.ExpiresAt.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid state", fmt.Sprintf("Could not parse expires_at: %s", err))
+		return
+	}
+
+	var previousTokens map[string]string
+	resp.Diagnostics.Append(state.Tokens.ElementsAs(ctx, &previousTokens, false)...)
+
+	var previousParticipants map[string]AccessTokensParticipantModel
+	resp.Diagnostics.Append(state.Participants.ElementsAs(ctx, &previousParticipants, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	issuedAt := time.Now()
+
+	tokens, signed := r.signTokens(ctx, &data, previousTokens, previousParticipants, issuedAt, expiresAt, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Tokens = tokens
+	data.IssuedAt = state.IssuedAt
+	if signed {
+		data.IssuedAt = types.StringValue(formatTime(issuedAt))
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccessTokensResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AccessTokensResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the livekit API does not support deleting tokens, so we don't need to do anything here
+}
+
+// signTokens returns the tokens of all identities and participants of data,
+// and whether any of them was signed. Tokens in previousTokens are reused for
+// identities whose overrides did not change since previousParticipants.
+func (r *AccessTokensResource) signTokens(ctx context.Context, data *AccessTokensResourceModel, previousTokens map[string]string,
+	previousParticipants map[string]AccessTokensParticipantModel, issuedAt time.Time, expiresAt time.Time, diags *diag.Diagnostics) (types.Map, bool) {
+	var identities []string
+	diags.Append(data.Identities.ElementsAs(ctx, &identities, false)...)
+
+	var participants map[string]AccessTokensParticipantModel
+	diags.Append(data.Participants.ElementsAs(ctx, &participants, false)...)
+
+	var canPublishSources []string
+	diags.Append(data.CanPublishSources.ElementsAs(ctx, &canPublishSources, false)...)
+
+	if diags.HasError() {
+		return types.MapNull(types.StringType), false
+	}
+
+	// Participants without overrides get the grants of the resource.
+	for _, identity := range identities {
+		if _, ok := participants[identity]; !ok {
+			if participants == nil {
+				participants = make(map[string]AccessTokensParticipantModel, len(identities))
+			}
+			participants[identity] = AccessTokensParticipantModel{}
+		}
+	}
+
+	apiKey, apiSecret, err := r.client.SigningKey(data.KeyId.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("key_id"), "Invalid key_id", err.Error())
+		return types.MapNull(types.StringType), false
+	}
+
+	override := func(value types.Bool, defaultValue types.Bool) *bool {
+		if value.IsNull() {
+			return defaultValue.ValueBoolPointer()
+		}
+		return value.ValueBoolPointer()
+	}
+
+	signed := false
+	tokens := make(map[string]string, len(participants))
+	for identity, participant := range participants {
+		if token, ok := previousTokens[identity]; ok && participant == previousParticipants[identity] {
+			tokens[identity] = token
+			continue
+		}
+
+		grants := &auth.ClaimGrants{
+			Identity: identity,
+			Name:     participant.Name.ValueString(),
+			Metadata: participant.Metadata.ValueString(),
+			Video: &auth.VideoGrant{
+				Room:              r.client.RoomName(data.Room.ValueString()),
+				RoomJoin:          true,
+				CanPublish:        override(participant.CanPublish, data.CanPublish),
+				CanPublishData:    override(participant.CanPublishData, data.CanPublishData),
+				CanSubscribe:      override(participant.CanSubscribe, data.CanSubscribe),
+				CanPublishSources: canPublishSources,
+				Hidden:            data.Hidden.ValueBool(),
+			},
+		}
+
+		token, err := signToken(apiKey, apiSecret, grants, nil, jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			NotBefore: jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		}, nil)
+		if err != nil {
+			diags.AddError("Error creating JWT", err.Error())
+			return types.MapNull(types.StringType), false
+		}
+		tokens[identity] = token
+		signed = true
+	}
+
+	value, d := types.MapValueFrom(ctx, types.StringType, tokens)
+	diags.Append(d...)
+
+	return value, signed
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CliConfig mirrors the configuration file written by the Livekit CLI.
+type CliConfig struct {
+	DefaultProject string             `yaml:"default_project"`
+	Projects       []CliProjectConfig `yaml:"projects"`
+}
+
+type CliProjectConfig struct {
+	Name      string `yaml:"name"`
+	Url       string `yaml:"url"`
+	ApiKey    string `yaml:"api_key"`
+	ApiSecret string `yaml:"api_secret"`
+}
+
+// defaultCliConfigFile returns the location the Livekit CLI writes its configuration to.
+func defaultCliConfigFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to determine home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".livekit", "cli.yaml"), nil
+}
+
+// loadCliProject reads the Livekit CLI configuration file and returns the named
+// project, or the default project of the file when name is empty.
+func loadCliProject(configFile string, name string) (*CliProjectConfig, error) {
+	if configFile == "" {
+		var err error
+		if configFile, err = defaultCliConfigFile(); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Livekit CLI config: %w", err)
+	}
+
+	var config CliConfig
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("error parsing Livekit CLI config %s: %w", configFile, err)
+	}
+
+	if name == "" {
+		name = config.DefaultProject
+	}
+	if name == "" {
+		return nil, fmt.Errorf("no project selected and %s has no default project", configFile)
+	}
+
+	for _, project := range config.Projects {
+		if project.Name == name {
+			return &project, nil
+		}
+	}
+
+	return nil, fmt.Errorf("project %q not found in %s", name, configFile)
+}
@@ -0,0 +1,432 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/twitchtv/twirp"
+)
+
+// LivekitClient holds the credentials and API clients configured on the provider.
+// It is handed to resources and data sources as provider data.
+type LivekitClient struct {
+	ApiKey    string
+	ApiSecret string
+	Url       string
+
+	// PreviousApiSecret is the secret of ApiKey before its last rotation. It
+	// is only used to recognize tokens signed before the rotation.
+	PreviousApiSecret string
+
+	// Keys holds additional named key/secret pairs that resources can select
+	// through their key_id attribute.
+	Keys map[string]string
+
+	// keyId names the key pair that signs server API calls, the default
+	// credentials when empty.
+	keyId string
+
+	// DefaultTokenTtl is the validity used for access tokens without valid_for.
+	DefaultTokenTtl string
+
+	// RoomNamePrefix is prepended to every room name sent to the server or
+	// written into a token.
+	RoomNamePrefix string
+
+	// RoomService and IngressService are nil when no server url is
+	// configured.
+	RoomService    livekit.RoomService
+	IngressService livekit.Ingress
+
+	httpClient   *http.Client
+	interceptors twirp.ClientOption
+	endpoints    *endpointCache
+}
+
+// endpointCache shares the clients for overridden endpoints between all
+// copies of a LivekitClient.
+type endpointCache struct {
+	mu      sync.Mutex
+	clients map[string]*LivekitClient
+}
+
+// LivekitClientConfig holds the resolved provider settings used to build a LivekitClient.
+type LivekitClientConfig struct {
+	Url       string
+	ApiKey    string
+	ApiSecret string
+	Keys      map[string]string
+
+	PreviousApiSecret string
+
+	DefaultTokenTtl string
+	RoomNamePrefix  string
+
+	MaxRetries      int
+	RetryMinBackoff time.Duration
+	RetryMaxBackoff time.Duration
+	RequestTimeout  time.Duration
+
+	MaxConcurrentRequests int
+	RequestsPerSecond     float64
+
+	CaCertPem          string
+	ClientCertPem      string
+	ClientKeyPem       string
+	InsecureSkipVerify bool
+
+	UserAgent string
+}
+
+func NewLivekitClient(config LivekitClientConfig) (*LivekitClient, error) {
+	httpClient, err := newHttpClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &LivekitClient{
+		ApiKey:    config.ApiKey,
+		ApiSecret: config.ApiSecret,
+		Keys:      config.Keys,
+
+		PreviousApiSecret: config.PreviousApiSecret,
+		DefaultTokenTtl:   config.DefaultTokenTtl,
+		RoomNamePrefix:    config.RoomNamePrefix,
+
+		httpClient: httpClient,
+		interceptors: twirp.WithClientInterceptors(
+			retryInterceptor(config.MaxRetries, config.RetryMinBackoff, config.RetryMaxBackoff),
+			limitInterceptor(config.MaxConcurrentRequests, config.RequestsPerSecond),
+			timeoutInterceptor(config.RequestTimeout),
+			loggingInterceptor(),
+		),
+		endpoints: &endpointCache{clients: make(map[string]*LivekitClient)},
+	}
+
+	client.connect(config.Url)
+
+	return client, nil
+}
+
+// connect points the API clients at the server at url.
+func (c *LivekitClient) connect(url string) {
+	c.Url = url
+	c.RoomService = nil
+	c.IngressService = nil
+
+	if url != "" {
+		c.RoomService = livekit.NewRoomServiceProtobufClient(toHttpUrl(url), c.httpClient, c.interceptors)
+		c.IngressService = livekit.NewIngressProtobufClient(toHttpUrl(url), c.httpClient, c.interceptors)
+	}
+}
+
+// ForEndpoint returns a client for the server at url that signs server API
+// calls with the key pair named keyId, sharing retry and rate limits with c.
+// An empty url keeps the server of c, an empty keyId the default credentials.
+// The key pair is checked by RequireServer.
+func (c *LivekitClient) ForEndpoint(url string, keyId string) (*LivekitClient, error) {
+	client := *c
+	client.keyId = keyId
+
+	if url != "" && url != c.Url {
+		if err := validateServerUrl(url); err != nil {
+			return nil, err
+		}
+
+		// Only the connection is shared, the credentials stay those of c.
+		connected := c.endpoints.get(c, url)
+		client.Url = connected.Url
+		client.RoomService = connected.RoomService
+		client.IngressService = connected.IngressService
+	}
+
+	return &client, nil
+}
+
+// WithCredentials returns a copy of c that signs with apiKey and apiSecret
+// instead of the provider credentials, e.g. of another Livekit project.
+func (c *LivekitClient) WithCredentials(apiKey string, apiSecret string) *LivekitClient {
+	client := *c
+	client.ApiKey = apiKey
+	client.ApiSecret = apiSecret
+	client.PreviousApiSecret = ""
+	client.Keys = nil
+	client.keyId = ""
+
+	return &client
+}
+
+// get returns the cached client connected to the server at url, created
+// from c.
+func (e *endpointCache) get(c *LivekitClient, url string) *LivekitClient {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if client, ok := e.clients[url]; ok {
+		return client
+	}
+
+	client := *c
+	client.connect(url)
+	e.clients[url] = &client
+
+	return &client
+}
+
+// timeoutInterceptor bounds every attempt of an API call by timeout. A zero
+// timeout leaves calls unbounded.
+func timeoutInterceptor(timeout time.Duration) twirp.Interceptor {
+	return func(next twirp.Method) twirp.Method {
+		if timeout == 0 {
+			return next
+		}
+
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// newHttpClient builds the http client used for server API calls, applying the
+// TLS settings of the provider.
+func newHttpClient(config LivekitClientConfig) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if config.CaCertPem != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(config.CaCertPem)) {
+			return nil, fmt.Errorf("ca_cert_pem does not contain any valid PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCertPem != "" || config.ClientKeyPem != "" {
+		cert, err := tls.X509KeyPair([]byte(config.ClientCertPem), []byte(config.ClientKeyPem))
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Transport: &userAgentTransport{
+			userAgent: config.UserAgent,
+			next:      &loggingTransport{next: transport},
+		},
+	}, nil
+}
+
+// userAgentTransport sets the User-Agent header of every request.
+type userAgentTransport struct {
+	userAgent string
+	next      http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.userAgent != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// SigningKey returns the key/secret pair named by keyId, or the default
+// provider credentials when keyId is empty.
+func (c *LivekitClient) SigningKey(keyId string) (string, string, error) {
+	if keyId == "" {
+		if c.ApiKey == "" || c.ApiSecret == "" {
+			return "", "", &keyError{"no default api key configured, set key_id to one of the keys configured on the provider"}
+		}
+		return c.ApiKey, c.ApiSecret, nil
+	}
+
+	if keyId == c.ApiKey && c.ApiSecret != "" {
+		return c.ApiKey, c.ApiSecret, nil
+	}
+
+	secret, ok := c.Keys[keyId]
+	if !ok {
+		return "", "", &keyError{fmt.Sprintf("api key %q is not configured on the provider", keyId)}
+	}
+
+	return keyId, secret, nil
+}
+
+// keyError reports a key_id that selects none of the configured key pairs.
+type keyError struct {
+	message string
+}
+
+func (e *keyError) Error() string {
+	return e.message
+}
+
+// RoomName returns the server side name of a room configured as name.
+func (c *LivekitClient) RoomName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return c.RoomNamePrefix + name
+}
+
+// ConfiguredRoomName is the inverse of RoomName.
+func (c *LivekitClient) ConfiguredRoomName(roomName string) string {
+	return strings.TrimPrefix(roomName, c.RoomNamePrefix)
+}
+
+// TokenSigner tells which of the configured secrets signed a token.
+type TokenSigner int
+
+const (
+	// TokenSignerUnknown means none of the configured secrets signed the token.
+	TokenSignerUnknown TokenSigner = iota
+	TokenSignerCurrentSecret
+	TokenSignerPreviousSecret
+)
+
+// TokenSigner verifies the signature of token against the secrets configured
+// for its issuing API key. Expiry is not checked.
+func (c *LivekitClient) TokenSigner(token string) TokenSigner {
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		return TokenSignerUnknown
+	}
+
+	if claims.Issuer == c.ApiKey {
+		if tokenSignedWith(token, c.ApiSecret) {
+			return TokenSignerCurrentSecret
+		}
+		if tokenSignedWith(token, c.PreviousApiSecret) {
+			return TokenSignerPreviousSecret
+		}
+		return TokenSignerUnknown
+	}
+
+	if tokenSignedWith(token, c.Keys[claims.Issuer]) {
+		return TokenSignerCurrentSecret
+	}
+	return TokenSignerUnknown
+}
+
+// RequireServer reports an error when the provider has no server url or the
+// client no key pair to sign with, which every server API call needs.
+func (c *LivekitClient) RequireServer() error {
+	if c.RoomService == nil {
+		return fmt.Errorf("the provider has no Livekit server url configured. " +
+			"Set the url value in the provider configuration or use the LIVEKIT_URL environment variable")
+	}
+
+	_, _, err := c.SigningKey(c.keyId)
+	return err
+}
+
+// AuthContext returns a context carrying the authorization header for a server
+// API call that needs the given grant, signed with the key pair selected for c.
+func (c *LivekitClient) AuthContext(ctx context.Context, grant *auth.VideoGrant) (context.Context, error) {
+	apiKey, apiSecret, err := c.SigningKey(c.keyId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	token, err := signToken(apiKey, apiSecret, &auth.ClaimGrants{Video: grant}, nil, jwt.RegisteredClaims{
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(10 * time.Minute)),
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating API token: %w", err)
+	}
+
+	header := make(http.Header)
+	header.Set("Authorization", "Bearer "+token)
+
+	return twirp.WithHTTPRequestHeaders(ctx, header)
+}
+
+// ValidateCredentials performs a cheap authenticated call with every
+// configured key pair to verify that the server accepts them.
+func (c *LivekitClient) ValidateCredentials(ctx context.Context) error {
+	if err := c.RequireServer(); err != nil {
+		return err
+	}
+
+	var keyIds []string
+	if c.ApiKey != "" {
+		keyIds = append(keyIds, "")
+	}
+	for keyId := range c.Keys {
+		if keyId != c.ApiKey {
+			keyIds = append(keyIds, keyId)
+		}
+	}
+	slices.Sort(keyIds)
+
+	for _, keyId := range keyIds {
+		client, err := c.ForEndpoint("", keyId)
+		if err != nil {
+			return err
+		}
+
+		authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomList: true})
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.RoomService.ListRooms(authCtx, &livekit.ListRoomsRequest{}); err != nil {
+			if keyId == "" {
+				keyId = c.ApiKey
+			}
+			return fmt.Errorf("api key %q: %w", keyId, err)
+		}
+	}
+
+	return nil
+}
+
+// isNotFound reports whether err is the server telling that the addressed
+// room, participant or ingress does not exist.
+func isNotFound(err error) bool {
+	var twerr twirp.Error
+	return errors.As(err, &twerr) && twerr.Code() == twirp.NotFound
+}
+
+// toHttpUrl converts websocket urls, as used by the client SDKs, into the http
+// urls the server API listens on.
+func toHttpUrl(url string) string {
+	if strings.HasPrefix(url, "ws") {
+		return strings.Replace(url, "ws", "http", 1)
+	}
+	return url
+}
+
+func toWebsocketUrl(url string) string {
+	if strings.HasPrefix(url, "http") {
+		return strings.Replace(url, "http", "ws", 1)
+	}
+	return url
+}
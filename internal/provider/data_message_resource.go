@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+)
+
+var _ resource.Resource = &DataMessageResource{}
+
+func NewDataMessageResource() resource.Resource {
+	return &DataMessageResource{}
+}
+
+// DataMessageResource defines the resource implementation.
+type DataMessageResource struct {
+	client *LivekitClient
+}
+
+// DataMessageResourceModel describes the resource data model.
+type DataMessageResourceModel struct {
+	Id                    types.String `tfsdk:"id"`
+	Room                  types.String `tfsdk:"room"`
+	Endpoint              types.String `tfsdk:"endpoint"`
+	KeyId                 types.String `tfsdk:"key_id"`
+	Payload               types.String `tfsdk:"payload"`
+	Topic                 types.String `tfsdk:"topic"`
+	Reliable              types.Bool   `tfsdk:"reliable"`
+	DestinationIdentities types.List   `tfsdk:"destination_identities"`
+	Keepers               types.Map    `tfsdk:"keepers"`
+	SentAt                types.String `tfsdk:"sent_at"`
+}
+
+func (r *DataMessageResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_data_message"
+}
+
+func (r *DataMessageResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data message sent to the participants of a Livekit room on apply",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, a random UUID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"room": schema.StringAttribute{
+				MarkdownDescription: "Name of the room, prefixed with the provider room_name_prefix on the server",
+				Required:            true,
+				Validators: []validator.String{
+					roomName(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Livekit server url of the room instead of the provider url",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "API key to sign the server API calls with, one of the keys configured on the provider. Defaults to the provider api_key",
+				Optional:            true,
+			},
+			"payload": schema.StringAttribute{
+				MarkdownDescription: "Payload of the message, e.g. a JSON document",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"topic": schema.StringAttribute{
+				MarkdownDescription: "Topic of the message",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"reliable": schema.BoolAttribute{
+				MarkdownDescription: "Deliver the message reliably, in order and retransmitted when lost. Defaults to true",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"destination_identities": schema.ListAttribute{
+				MarkdownDescription: "Identities of the participants to send the message to. All participants receive it when omitted",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that send the message again when they change, e.g. a deployment id",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"sent_at": schema.StringAttribute{
+				MarkdownDescription: "Time the message was sent as an RFC3339 timestamp",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DataMessageResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*LivekitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *LivekitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DataMessageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DataMessageResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client.ForEndpoint(data.Endpoint.ValueString(), data.KeyId.ValueString())
+	if err == nil {
+		err = client.RequireServer()
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot send data message", err.Error())
+		return
+	}
+
+	var destinationIdentities []string
+	resp.Diagnostics.Append(data.DestinationIdentities.ElementsAs(ctx, &destinationIdentities, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roomName := client.RoomName(data.Room.ValueString())
+
+	request := &livekit.SendDataRequest{
+		Room:                  roomName,
+		Data:                  []byte(data.Payload.ValueString()),
+		Kind:                  livekit.DataPacket_LOSSY,
+		DestinationIdentities: destinationIdentities,
+	}
+	if data.Reliable.ValueBool() {
+		request.Kind = livekit.DataPacket_RELIABLE
+	}
+	if !data.Topic.IsNull() {
+		topic := data.Topic.ValueString()
+		request.Topic = &topic
+	}
+
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomAdmin: true, Room: roomName})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	_, err = client.RoomService.SendData(authCtx, request)
+	if err != nil {
+		resp.Diagnostics.AddError("Error sending data message", fmt.Sprintf("Could not send data message to room %q: %s", roomName, err))
+		return
+	}
+
+	data.Id = types.StringValue(uuid.NewString())
+	data.SentAt = types.StringValue(formatTime(time.Now()))
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DataMessageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// A sent message cannot be read back, the state is kept as is.
+}
+
+func (r *DataMessageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DataMessageResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// nothing to do, key_id only signs later API calls and the other fields
+	// require replacement when they change.
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DataMessageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// A sent message cannot be recalled.
+}
@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	day   = 24 * time.Hour
+	week  = 7 * day
+	month = 30 * day
+	year  = 365 * day
+)
+
+// durationUnits maps the units accepted by parseTokenDuration to their length.
+// Months and years have a fixed length of 30 and 365 days.
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  day,
+	"w":  week,
+	"mo": month,
+	"y":  year,
+}
+
+// durationPart matches one number and unit of a duration. Longer units come
+// first so that mo and ms are not read as minutes.
+var durationPart = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)(mo|ms|ns|us|µs|s|m|h|d|w|y)`)
+
+// parseTokenDuration parses a duration like time.ParseDuration does, and
+// additionally accepts days (d), weeks (w), months (mo) and years (y), e.g.
+// 1w, 1d12h or 1y6mo.
+func parseTokenDuration(value string) (time.Duration, error) {
+	if value == "0" {
+		return 0, nil
+	}
+
+	if value == "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	var total time.Duration
+	for rest := value; rest != ""; {
+		match := durationPart.FindStringSubmatch(rest)
+		if match == nil {
+			return 0, fmt.Errorf("invalid duration %q, expected e.g. 90m, 12h, 7d, 2w, 6mo or 1y", value)
+		}
+
+		number, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+		}
+
+		// time.Duration holds about 292 years, longer durations would wrap
+		// around instead of failing.
+		part := number * float64(durationUnits[match[2]])
+		if part >= float64(math.MaxInt64-total) {
+			return 0, fmt.Errorf("invalid duration %q, durations are limited to about 292 years", value)
+		}
+
+		total += time.Duration(part)
+		rest = rest[len(match[0]):]
+	}
+
+	return total, nil
+}
+
+// sameDuration reports whether two durations are equal once parsed, e.g. 1h
+// and 60m.
+func sameDuration(a, b string) bool {
+	da, errA := parseTokenDuration(a)
+	db, errB := parseTokenDuration(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return da == db
+}
+
+var _ basetypes.StringTypable = DurationType{}
+var _ basetypes.StringValuableWithSemanticEquals = DurationValue{}
+
+// DurationType is a string attribute type holding a duration in the format of
+// parseTokenDuration. Values denoting the same duration, e.g. 1h and 60m, are
+// semantically equal.
+type DurationType struct {
+	basetypes.StringType
+}
+
+func (t DurationType) Equal(o attr.Type) bool {
+	other, ok := o.(DurationType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t DurationType) String() string {
+	return "DurationType"
+}
+
+func (t DurationType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return DurationValue{StringValue: in}, nil
+}
+
+func (t DurationType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return DurationValue{StringValue: stringValue}, nil
+}
+
+func (t DurationType) ValueType(ctx context.Context) attr.Value {
+	return DurationValue{}
+}
+
+// DurationValue is a value of DurationType.
+type DurationValue struct {
+	basetypes.StringValue
+}
+
+// NewDurationValue returns a known DurationValue.
+func NewDurationValue(value string) DurationValue {
+	return DurationValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewDurationUnknown returns an unknown DurationValue.
+func NewDurationUnknown() DurationValue {
+	return DurationValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+// secondsDuration returns a DurationValue of seconds, or an unknown value
+// while seconds is unknown.
+func secondsDuration(seconds types.Int64) DurationValue {
+	if seconds.IsUnknown() {
+		return NewDurationUnknown()
+	}
+	return NewDurationValue(fmt.Sprintf("%ds", seconds.ValueInt64()))
+}
+
+// NewDurationNull returns a null DurationValue.
+func NewDurationNull() DurationValue {
+	return DurationValue{StringValue: basetypes.NewStringNull()}
+}
+
+func (v DurationValue) Equal(o attr.Value) bool {
+	other, ok := o.(DurationValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v DurationValue) Type(ctx context.Context) attr.Type {
+	return DurationType{}
+}
+
+func (v DurationValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(DurationValue)
+	if !ok {
+		var diags diag.Diagnostics
+		diags.AddError("Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this issue to the provider developers.", v, newValuable))
+		return false, diags
+	}
+
+	return sameDuration(v.ValueString(), newValue.ValueString()), nil
+}
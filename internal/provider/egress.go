@@ -0,0 +1,568 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+// RoomEgressModel describes the egress block of the room resource.
+type RoomEgressModel struct {
+	RoomComposite types.Object `tfsdk:"room_composite"`
+	Participant   types.Object `tfsdk:"participant"`
+	Tracks        types.Object `tfsdk:"tracks"`
+}
+
+// RoomCompositeEgressModel describes the room_composite block of the egress
+// block.
+type RoomCompositeEgressModel struct {
+	Layout         types.String `tfsdk:"layout"`
+	AudioOnly      types.Bool   `tfsdk:"audio_only"`
+	VideoOnly      types.Bool   `tfsdk:"video_only"`
+	CustomBaseUrl  types.String `tfsdk:"custom_base_url"`
+	Preset         types.String `tfsdk:"preset"`
+	FileOutputs    types.List   `tfsdk:"file_output"`
+	StreamOutputs  types.List   `tfsdk:"stream_output"`
+	SegmentOutputs types.List   `tfsdk:"segment_output"`
+}
+
+// ParticipantEgressModel describes the participant block of the egress
+// block.
+type ParticipantEgressModel struct {
+	Preset         types.String `tfsdk:"preset"`
+	FileOutputs    types.List   `tfsdk:"file_output"`
+	SegmentOutputs types.List   `tfsdk:"segment_output"`
+}
+
+// TrackEgressModel describes the tracks block of the egress block.
+type TrackEgressModel struct {
+	Filepath        types.String `tfsdk:"filepath"`
+	DisableManifest types.Bool   `tfsdk:"disable_manifest"`
+	S3              types.Object `tfsdk:"s3"`
+	Gcp             types.Object `tfsdk:"gcp"`
+	Azure           types.Object `tfsdk:"azure"`
+}
+
+// EgressFileOutputModel describes a file_output block.
+type EgressFileOutputModel struct {
+	FileType        types.String `tfsdk:"file_type"`
+	Filepath        types.String `tfsdk:"filepath"`
+	DisableManifest types.Bool   `tfsdk:"disable_manifest"`
+	S3              types.Object `tfsdk:"s3"`
+	Gcp             types.Object `tfsdk:"gcp"`
+	Azure           types.Object `tfsdk:"azure"`
+}
+
+// EgressStreamOutputModel describes a stream_output block.
+type EgressStreamOutputModel struct {
+	Protocol types.String `tfsdk:"protocol"`
+	Urls     types.List   `tfsdk:"urls"`
+}
+
+// EgressSegmentOutputModel describes a segment_output block.
+type EgressSegmentOutputModel struct {
+	FilenamePrefix   types.String `tfsdk:"filename_prefix"`
+	PlaylistName     types.String `tfsdk:"playlist_name"`
+	LivePlaylistName types.String `tfsdk:"live_playlist_name"`
+	SegmentDuration  types.Int64  `tfsdk:"segment_duration"`
+	DisableManifest  types.Bool   `tfsdk:"disable_manifest"`
+	S3               types.Object `tfsdk:"s3"`
+	Gcp              types.Object `tfsdk:"gcp"`
+	Azure            types.Object `tfsdk:"azure"`
+}
+
+// EgressS3Model describes the s3 block of an output.
+type EgressS3Model struct {
+	AccessKey      types.String `tfsdk:"access_key"`
+	Secret         types.String `tfsdk:"secret"`
+	Region         types.String `tfsdk:"region"`
+	Endpoint       types.String `tfsdk:"endpoint"`
+	Bucket         types.String `tfsdk:"bucket"`
+	ForcePathStyle types.Bool   `tfsdk:"force_path_style"`
+}
+
+// EgressGcpModel describes the gcp block of an output.
+type EgressGcpModel struct {
+	Credentials types.String `tfsdk:"credentials"`
+	Bucket      types.String `tfsdk:"bucket"`
+}
+
+// EgressAzureModel describes the azure block of an output.
+type EgressAzureModel struct {
+	AccountName   types.String `tfsdk:"account_name"`
+	AccountKey    types.String `tfsdk:"account_key"`
+	ContainerName types.String `tfsdk:"container_name"`
+}
+
+// egressPresets returns the names of the encoding presets known to the
+// protocol, e.g. H264_720P_30.
+func egressPresets() []string {
+	var presets []string
+	for name := range livekit.EncodingOptionsPreset_value {
+		presets = append(presets, name)
+	}
+	slices.Sort(presets)
+	return presets
+}
+
+// egressUploadBlocks returns the s3, gcp and azure blocks of an output.
+func egressUploadBlocks() map[string]schema.Block {
+	return map[string]schema.Block{
+		"s3": schema.SingleNestedBlock{
+			MarkdownDescription: "Upload to S3 or an S3 compatible storage",
+			Attributes: map[string]schema.Attribute{
+				"access_key": schema.StringAttribute{
+					MarkdownDescription: "Access key id",
+					Optional:            true,
+				},
+				"secret": schema.StringAttribute{
+					MarkdownDescription: "Secret access key",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"region": schema.StringAttribute{
+					MarkdownDescription: "Region of the bucket",
+					Optional:            true,
+				},
+				"endpoint": schema.StringAttribute{
+					MarkdownDescription: "Endpoint of an S3 compatible storage",
+					Optional:            true,
+				},
+				"bucket": schema.StringAttribute{
+					MarkdownDescription: "Name of the bucket",
+					Required:            true,
+				},
+				"force_path_style": schema.BoolAttribute{
+					MarkdownDescription: "Use path style addressing of the bucket",
+					Optional:            true,
+				},
+			},
+		},
+		"gcp": schema.SingleNestedBlock{
+			MarkdownDescription: "Upload to Google Cloud Storage",
+			Attributes: map[string]schema.Attribute{
+				"credentials": schema.StringAttribute{
+					MarkdownDescription: "Service account credentials JSON",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"bucket": schema.StringAttribute{
+					MarkdownDescription: "Name of the bucket",
+					Required:            true,
+				},
+			},
+		},
+		"azure": schema.SingleNestedBlock{
+			MarkdownDescription: "Upload to Azure Blob Storage",
+			Attributes: map[string]schema.Attribute{
+				"account_name": schema.StringAttribute{
+					MarkdownDescription: "Name of the storage account",
+					Required:            true,
+				},
+				"account_key": schema.StringAttribute{
+					MarkdownDescription: "Key of the storage account",
+					Required:            true,
+					Sensitive:           true,
+				},
+				"container_name": schema.StringAttribute{
+					MarkdownDescription: "Name of the container",
+					Required:            true,
+				},
+			},
+		},
+	}
+}
+
+// egressFileOutputBlock returns the block of a file output.
+func egressFileOutputBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Record to a file",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"file_type": schema.StringAttribute{
+					MarkdownDescription: "Container format: `mp4` or `ogg`. Defaults to the format fitting the tracks",
+					Optional:            true,
+					Validators: []validator.String{
+						stringOneOf("mp4", "ogg"),
+					},
+				},
+				"filepath": schema.StringAttribute{
+					MarkdownDescription: "Path of the file, may contain templates like `{room_name}` and `{time}`",
+					Optional:            true,
+				},
+				"disable_manifest": schema.BoolAttribute{
+					MarkdownDescription: "Do not upload a JSON manifest next to the file",
+					Optional:            true,
+				},
+			},
+			Blocks: egressUploadBlocks(),
+		},
+	}
+}
+
+// egressStreamOutputBlock returns the block of a stream output.
+func egressStreamOutputBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Stream to RTMP or SRT servers",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"protocol": schema.StringAttribute{
+					MarkdownDescription: "Streaming protocol: `rtmp` or `srt`. Defaults to `rtmp`",
+					Optional:            true,
+					Validators: []validator.String{
+						stringOneOf("rtmp", "srt"),
+					},
+				},
+				"urls": schema.ListAttribute{
+					MarkdownDescription: "Urls to stream to, including the stream keys",
+					ElementType:         types.StringType,
+					Required:            true,
+					Sensitive:           true,
+				},
+			},
+		},
+	}
+}
+
+// egressSegmentOutputBlock returns the block of a segmented HLS output.
+func egressSegmentOutputBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Record to HLS segments",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"filename_prefix": schema.StringAttribute{
+					MarkdownDescription: "Prefix of the segment files",
+					Optional:            true,
+				},
+				"playlist_name": schema.StringAttribute{
+					MarkdownDescription: "Name of the playlist file",
+					Optional:            true,
+				},
+				"live_playlist_name": schema.StringAttribute{
+					MarkdownDescription: "Name of the live playlist file, only listing the latest segments",
+					Optional:            true,
+				},
+				"segment_duration": schema.Int64Attribute{
+					MarkdownDescription: "Length of the segments in seconds",
+					Optional:            true,
+					Validators: []validator.Int64{
+						positiveInt64(),
+					},
+				},
+				"disable_manifest": schema.BoolAttribute{
+					MarkdownDescription: "Do not upload a JSON manifest next to the segments",
+					Optional:            true,
+				},
+			},
+			Blocks: egressUploadBlocks(),
+		},
+	}
+}
+
+// roomEgressBlocks returns the blocks of the egress block of the room
+// resource.
+func roomEgressBlocks() map[string]schema.Block {
+	return map[string]schema.Block{
+		"room_composite": schema.SingleNestedBlock{
+			MarkdownDescription: "Record or stream the composed room, as rendered by the egress layout",
+			Attributes: map[string]schema.Attribute{
+				"layout": schema.StringAttribute{
+					MarkdownDescription: "Layout of the composition, e.g. `grid` or `speaker`",
+					Optional:            true,
+				},
+				"audio_only": schema.BoolAttribute{
+					MarkdownDescription: "Only record the audio of the room",
+					Optional:            true,
+				},
+				"video_only": schema.BoolAttribute{
+					MarkdownDescription: "Only record the video of the room",
+					Optional:            true,
+				},
+				"custom_base_url": schema.StringAttribute{
+					MarkdownDescription: "Url of a custom recording template",
+					Optional:            true,
+				},
+				"preset": schema.StringAttribute{
+					MarkdownDescription: "Encoding preset, e.g. `H264_1080P_30`",
+					Optional:            true,
+					Validators: []validator.String{
+						stringOneOf(egressPresets()...),
+					},
+				},
+			},
+			Blocks: map[string]schema.Block{
+				"file_output":    egressFileOutputBlock(),
+				"stream_output":  egressStreamOutputBlock(),
+				"segment_output": egressSegmentOutputBlock(),
+			},
+		},
+		"participant": schema.SingleNestedBlock{
+			MarkdownDescription: "Record every participant, composing their camera and microphone tracks",
+			Attributes: map[string]schema.Attribute{
+				"preset": schema.StringAttribute{
+					MarkdownDescription: "Encoding preset, e.g. `H264_720P_30`",
+					Optional:            true,
+					Validators: []validator.String{
+						stringOneOf(egressPresets()...),
+					},
+				},
+			},
+			Blocks: map[string]schema.Block{
+				"file_output":    egressFileOutputBlock(),
+				"segment_output": egressSegmentOutputBlock(),
+			},
+		},
+		"tracks": schema.SingleNestedBlock{
+			MarkdownDescription: "Record every published track to its own file, without transcoding",
+			Attributes: map[string]schema.Attribute{
+				"filepath": schema.StringAttribute{
+					MarkdownDescription: "Path of the files, may contain templates like `{room_name}`, `{publisher_identity}` and `{track_id}`",
+					Optional:            true,
+				},
+				"disable_manifest": schema.BoolAttribute{
+					MarkdownDescription: "Do not upload a JSON manifest next to the files",
+					Optional:            true,
+				},
+			},
+			Blocks: egressUploadBlocks(),
+		},
+	}
+}
+
+// roomEgress returns the RoomEgress of the egress block value, or nil when
+// it is not set.
+func roomEgress(ctx context.Context, value types.Object, diags *diag.Diagnostics) *livekit.RoomEgress {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var egress RoomEgressModel
+	diags.Append(value.As(ctx, &egress, basetypes.ObjectAsOptions{})...)
+
+	if diags.HasError() {
+		return nil
+	}
+
+	result := &livekit.RoomEgress{}
+
+	if !egress.RoomComposite.IsNull() && !egress.RoomComposite.IsUnknown() {
+		var composite RoomCompositeEgressModel
+		diags.Append(egress.RoomComposite.As(ctx, &composite, basetypes.ObjectAsOptions{})...)
+
+		if diags.HasError() {
+			return nil
+		}
+
+		result.Room = &livekit.RoomCompositeEgressRequest{
+			Layout:         composite.Layout.ValueString(),
+			AudioOnly:      composite.AudioOnly.ValueBool(),
+			VideoOnly:      composite.VideoOnly.ValueBool(),
+			CustomBaseUrl:  composite.CustomBaseUrl.ValueString(),
+			FileOutputs:    egressFileOutputs(ctx, composite.FileOutputs, diags),
+			StreamOutputs:  egressStreamOutputs(ctx, composite.StreamOutputs, diags),
+			SegmentOutputs: egressSegmentOutputs(ctx, composite.SegmentOutputs, diags),
+		}
+
+		if !composite.Preset.IsNull() {
+			result.Room.Options = &livekit.RoomCompositeEgressRequest_Preset{
+				Preset: livekit.EncodingOptionsPreset(livekit.EncodingOptionsPreset_value[composite.Preset.ValueString()]),
+			}
+		}
+	}
+
+	if !egress.Participant.IsNull() && !egress.Participant.IsUnknown() {
+		var participant ParticipantEgressModel
+		diags.Append(egress.Participant.As(ctx, &participant, basetypes.ObjectAsOptions{})...)
+
+		if diags.HasError() {
+			return nil
+		}
+
+		result.Participant = &livekit.AutoParticipantEgress{
+			FileOutputs:    egressFileOutputs(ctx, participant.FileOutputs, diags),
+			SegmentOutputs: egressSegmentOutputs(ctx, participant.SegmentOutputs, diags),
+		}
+
+		if !participant.Preset.IsNull() {
+			result.Participant.Options = &livekit.AutoParticipantEgress_Preset{
+				Preset: livekit.EncodingOptionsPreset(livekit.EncodingOptionsPreset_value[participant.Preset.ValueString()]),
+			}
+		}
+	}
+
+	if !egress.Tracks.IsNull() && !egress.Tracks.IsUnknown() {
+		var tracks TrackEgressModel
+		diags.Append(egress.Tracks.As(ctx, &tracks, basetypes.ObjectAsOptions{})...)
+
+		if diags.HasError() {
+			return nil
+		}
+
+		result.Tracks = &livekit.AutoTrackEgress{
+			Filepath:        tracks.Filepath.ValueString(),
+			DisableManifest: tracks.DisableManifest.ValueBool(),
+		}
+
+		s3, gcp, azure := egressUpload(ctx, tracks.S3, tracks.Gcp, tracks.Azure, diags)
+		switch {
+		case s3 != nil:
+			result.Tracks.Output = &livekit.AutoTrackEgress_S3{S3: s3}
+		case gcp != nil:
+			result.Tracks.Output = &livekit.AutoTrackEgress_Gcp{Gcp: gcp}
+		case azure != nil:
+			result.Tracks.Output = &livekit.AutoTrackEgress_Azure{Azure: azure}
+		}
+	}
+
+	return result
+}
+
+// egressFileOutputs returns the file outputs of a list of file_output blocks.
+func egressFileOutputs(ctx context.Context, value types.List, diags *diag.Diagnostics) []*livekit.EncodedFileOutput {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var outputs []EgressFileOutputModel
+	diags.Append(value.ElementsAs(ctx, &outputs, false)...)
+
+	var result []*livekit.EncodedFileOutput
+	for _, output := range outputs {
+		file := &livekit.EncodedFileOutput{
+			FileType:        livekit.EncodedFileType(livekit.EncodedFileType_value[strings.ToUpper(output.FileType.ValueString())]),
+			Filepath:        output.Filepath.ValueString(),
+			DisableManifest: output.DisableManifest.ValueBool(),
+		}
+
+		s3, gcp, azure := egressUpload(ctx, output.S3, output.Gcp, output.Azure, diags)
+		switch {
+		case s3 != nil:
+			file.Output = &livekit.EncodedFileOutput_S3{S3: s3}
+		case gcp != nil:
+			file.Output = &livekit.EncodedFileOutput_Gcp{Gcp: gcp}
+		case azure != nil:
+			file.Output = &livekit.EncodedFileOutput_Azure{Azure: azure}
+		}
+
+		result = append(result, file)
+	}
+	return result
+}
+
+// egressStreamOutputs returns the stream outputs of a list of stream_output
+// blocks.
+func egressStreamOutputs(ctx context.Context, value types.List, diags *diag.Diagnostics) []*livekit.StreamOutput {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var outputs []EgressStreamOutputModel
+	diags.Append(value.ElementsAs(ctx, &outputs, false)...)
+
+	var result []*livekit.StreamOutput
+	for _, output := range outputs {
+		stream := &livekit.StreamOutput{
+			Protocol: livekit.StreamProtocol(livekit.StreamProtocol_value[strings.ToUpper(output.Protocol.ValueString())]),
+		}
+		diags.Append(output.Urls.ElementsAs(ctx, &stream.Urls, false)...)
+
+		result = append(result, stream)
+	}
+	return result
+}
+
+// egressSegmentOutputs returns the segmented outputs of a list of
+// segment_output blocks.
+func egressSegmentOutputs(ctx context.Context, value types.List, diags *diag.Diagnostics) []*livekit.SegmentedFileOutput {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var outputs []EgressSegmentOutputModel
+	diags.Append(value.ElementsAs(ctx, &outputs, false)...)
+
+	var result []*livekit.SegmentedFileOutput
+	for _, output := range outputs {
+		segments := &livekit.SegmentedFileOutput{
+			FilenamePrefix:   output.FilenamePrefix.ValueString(),
+			PlaylistName:     output.PlaylistName.ValueString(),
+			LivePlaylistName: output.LivePlaylistName.ValueString(),
+			SegmentDuration:  uint32(output.SegmentDuration.ValueInt64()),
+			DisableManifest:  output.DisableManifest.ValueBool(),
+		}
+
+		s3, gcp, azure := egressUpload(ctx, output.S3, output.Gcp, output.Azure, diags)
+		switch {
+		case s3 != nil:
+			segments.Output = &livekit.SegmentedFileOutput_S3{S3: s3}
+		case gcp != nil:
+			segments.Output = &livekit.SegmentedFileOutput_Gcp{Gcp: gcp}
+		case azure != nil:
+			segments.Output = &livekit.SegmentedFileOutput_Azure{Azure: azure}
+		}
+
+		result = append(result, segments)
+	}
+	return result
+}
+
+// egressUpload returns the upload destination of the s3, gcp and azure
+// blocks of an output. All are nil when none is set, so that the egress
+// service uploads to the storage it is configured with.
+func egressUpload(ctx context.Context, s3Value, gcpValue, azureValue types.Object, diags *diag.Diagnostics) (*livekit.S3Upload, *livekit.GCPUpload, *livekit.AzureBlobUpload) {
+	configured := 0
+	for _, value := range []types.Object{s3Value, gcpValue, azureValue} {
+		if !value.IsNull() {
+			configured++
+		}
+	}
+
+	if configured > 1 {
+		diags.AddError("Conflicting egress uploads", "Only one of the s3, gcp and azure blocks can be set on an output.")
+		return nil, nil, nil
+	}
+
+	switch {
+	case !s3Value.IsNull() && !s3Value.IsUnknown():
+		var s3 EgressS3Model
+		diags.Append(s3Value.As(ctx, &s3, basetypes.ObjectAsOptions{})...)
+
+		return &livekit.S3Upload{
+			AccessKey:      s3.AccessKey.ValueString(),
+			Secret:         s3.Secret.ValueString(),
+			Region:         s3.Region.ValueString(),
+			Endpoint:       s3.Endpoint.ValueString(),
+			Bucket:         s3.Bucket.ValueString(),
+			ForcePathStyle: s3.ForcePathStyle.ValueBool(),
+		}, nil, nil
+	case !gcpValue.IsNull() && !gcpValue.IsUnknown():
+		var gcp EgressGcpModel
+		diags.Append(gcpValue.As(ctx, &gcp, basetypes.ObjectAsOptions{})...)
+
+		return nil, &livekit.GCPUpload{
+			Credentials: gcp.Credentials.ValueString(),
+			Bucket:      gcp.Bucket.ValueString(),
+		}, nil
+	case !azureValue.IsNull() && !azureValue.IsUnknown():
+		var azure EgressAzureModel
+		diags.Append(azureValue.As(ctx, &azure, basetypes.ObjectAsOptions{})...)
+
+		return nil, nil, &livekit.AzureBlobUpload{
+			AccountName:   azure.AccountName.ValueString(),
+			AccountKey:    azure.AccountKey.ValueString(),
+			ContainerName: azure.ContainerName.ValueString(),
+		}
+	}
+
+	return nil, nil, nil
+}
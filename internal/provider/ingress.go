@@ -0,0 +1,471 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+// IngressAudioModel describes the audio block of the ingress resource.
+type IngressAudioModel struct {
+	Name       types.String `tfsdk:"name"`
+	Source     types.String `tfsdk:"source"`
+	Preset     types.String `tfsdk:"preset"`
+	Codec      types.String `tfsdk:"codec"`
+	Bitrate    types.Int64  `tfsdk:"bitrate"`
+	Channels   types.Int64  `tfsdk:"channels"`
+	DisableDtx types.Bool   `tfsdk:"disable_dtx"`
+}
+
+// IngressVideoModel describes the video block of the ingress resource.
+type IngressVideoModel struct {
+	Name      types.String  `tfsdk:"name"`
+	Source    types.String  `tfsdk:"source"`
+	Preset    types.String  `tfsdk:"preset"`
+	Codec     types.String  `tfsdk:"codec"`
+	FrameRate types.Float64 `tfsdk:"frame_rate"`
+	Layers    types.List    `tfsdk:"layer"`
+}
+
+// IngressVideoLayerModel describes a simulcast layer of the video block.
+type IngressVideoLayerModel struct {
+	Quality types.String `tfsdk:"quality"`
+	Width   types.Int64  `tfsdk:"width"`
+	Height  types.Int64  `tfsdk:"height"`
+	Bitrate types.Int64  `tfsdk:"bitrate"`
+}
+
+// ingressAudioPresets returns the names of the audio encoding presets known
+// to the protocol, e.g. OPUS_STEREO_96KBPS.
+func ingressAudioPresets() []string {
+	var presets []string
+	for name := range livekit.IngressAudioEncodingPreset_value {
+		presets = append(presets, name)
+	}
+	slices.Sort(presets)
+	return presets
+}
+
+// ingressVideoPresets returns the names of the video encoding presets known
+// to the protocol, e.g. H264_720P_30FPS_3_LAYERS.
+func ingressVideoPresets() []string {
+	var presets []string
+	for name := range livekit.IngressVideoEncodingPreset_value {
+		presets = append(presets, name)
+	}
+	slices.Sort(presets)
+	return presets
+}
+
+// requiresReplaceIfRemoved replaces the ingress when an options block is
+// removed, as the server keeps the options of an update without them.
+func requiresReplaceIfRemoved() planmodifier.Object {
+	return objectplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !req.StateValue.IsNull() && req.PlanValue.IsNull()
+		},
+		"Removing the block replaces the ingress",
+		"Removing the block replaces the ingress",
+	)
+}
+
+// ingressAudioBlock returns the audio block of the ingress resource.
+func ingressAudioBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Encoding of the published audio track. Either preset or the encoding options can be set",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the audio track",
+				Optional:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Source of the audio track: microphone or screen_share_audio. Defaults to microphone",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf("microphone", "screen_share_audio"),
+				},
+			},
+			"preset": schema.StringAttribute{
+				MarkdownDescription: "Encoding preset, e.g. OPUS_STEREO_96KBPS",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(ingressAudioPresets()...),
+				},
+			},
+			"codec": schema.StringAttribute{
+				MarkdownDescription: "Audio codec: opus. Defaults to opus",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf("opus"),
+				},
+			},
+			"bitrate": schema.Int64Attribute{
+				MarkdownDescription: "Bitrate in bits per second",
+				Optional:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+			},
+			"channels": schema.Int64Attribute{
+				MarkdownDescription: "Number of channels, 1 for mono or 2 for stereo",
+				Optional:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+			},
+			"disable_dtx": schema.BoolAttribute{
+				MarkdownDescription: "Keep sending audio during silence instead of using discontinuous transmission, e.g. for music",
+				Optional:            true,
+			},
+		},
+		PlanModifiers: []planmodifier.Object{
+			requiresReplaceIfRemoved(),
+		},
+	}
+}
+
+// ingressAudio returns the IngressAudioOptions of the audio block value, or
+// nil when it is not set.
+func ingressAudio(ctx context.Context, value types.Object, diags *diag.Diagnostics) *livekit.IngressAudioOptions {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var audio IngressAudioModel
+	diags.Append(value.As(ctx, &audio, basetypes.ObjectAsOptions{})...)
+
+	if diags.HasError() {
+		return nil
+	}
+
+	result := &livekit.IngressAudioOptions{
+		Name: audio.Name.ValueString(),
+	}
+
+	switch audio.Source.ValueString() {
+	case "microphone":
+		result.Source = livekit.TrackSource_MICROPHONE
+	case "screen_share_audio":
+		result.Source = livekit.TrackSource_SCREEN_SHARE_AUDIO
+	}
+
+	options := !audio.Codec.IsNull() || !audio.Bitrate.IsNull() || !audio.Channels.IsNull() || !audio.DisableDtx.IsNull()
+
+	switch {
+	case !audio.Preset.IsNull() && options:
+		diags.AddAttributeError(path.Root("audio").AtName("preset"), "Conflicting audio encoding",
+			"Only one of preset and the codec, bitrate, channels and disable_dtx options can be set.")
+		return nil
+	case !audio.Preset.IsNull():
+		result.EncodingOptions = &livekit.IngressAudioOptions_Preset{
+			Preset: livekit.IngressAudioEncodingPreset(livekit.IngressAudioEncodingPreset_value[audio.Preset.ValueString()]),
+		}
+	case options:
+		result.EncodingOptions = &livekit.IngressAudioOptions_Options{
+			Options: &livekit.IngressAudioEncodingOptions{
+				AudioCodec: livekit.AudioCodec_OPUS,
+				Bitrate:    uint32(audio.Bitrate.ValueInt64()),
+				Channels:   uint32(audio.Channels.ValueInt64()),
+				DisableDtx: audio.DisableDtx.ValueBool(),
+			},
+		}
+	}
+
+	return result
+}
+
+// ingressVideoBlock returns the video block of the ingress resource.
+func ingressVideoBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Encoding of the published video track. Either preset or the encoding options can be set",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the video track",
+				Optional:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Source of the video track: camera or screen_share. Defaults to camera",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf("camera", "screen_share"),
+				},
+			},
+			"preset": schema.StringAttribute{
+				MarkdownDescription: "Encoding preset, e.g. H264_720P_30FPS_3_LAYERS",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(ingressVideoPresets()...),
+				},
+			},
+			"codec": schema.StringAttribute{
+				MarkdownDescription: "Video codec: h264_baseline, h264_main, h264_high or vp8. Defaults to the server setting",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf("h264_baseline", "h264_main", "h264_high", "vp8"),
+				},
+			},
+			"frame_rate": schema.Float64Attribute{
+				MarkdownDescription: "Frames per second of every layer",
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"layer": schema.ListNestedBlock{
+				MarkdownDescription: "Simulcast layer, subscribers receive the one fitting their bandwidth and size",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"quality": schema.StringAttribute{
+							MarkdownDescription: "Quality of the layer: low, medium or high",
+							Required:            true,
+							Validators: []validator.String{
+								stringOneOf("low", "medium", "high"),
+							},
+						},
+						"width": schema.Int64Attribute{
+							MarkdownDescription: "Width in pixels",
+							Required:            true,
+							Validators: []validator.Int64{
+								positiveInt64(),
+							},
+						},
+						"height": schema.Int64Attribute{
+							MarkdownDescription: "Height in pixels",
+							Required:            true,
+							Validators: []validator.Int64{
+								positiveInt64(),
+							},
+						},
+						"bitrate": schema.Int64Attribute{
+							MarkdownDescription: "Bitrate in bits per second",
+							Required:            true,
+							Validators: []validator.Int64{
+								positiveInt64(),
+							},
+						},
+					},
+				},
+			},
+		},
+		PlanModifiers: []planmodifier.Object{
+			requiresReplaceIfRemoved(),
+		},
+	}
+}
+
+// ingressVideo returns the IngressVideoOptions of the video block value, or
+// nil when it is not set.
+func ingressVideo(ctx context.Context, value types.Object, diags *diag.Diagnostics) *livekit.IngressVideoOptions {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var video IngressVideoModel
+	diags.Append(value.As(ctx, &video, basetypes.ObjectAsOptions{})...)
+
+	var layers []IngressVideoLayerModel
+	diags.Append(video.Layers.ElementsAs(ctx, &layers, false)...)
+
+	if diags.HasError() {
+		return nil
+	}
+
+	result := &livekit.IngressVideoOptions{
+		Name: video.Name.ValueString(),
+	}
+
+	switch video.Source.ValueString() {
+	case "camera":
+		result.Source = livekit.TrackSource_CAMERA
+	case "screen_share":
+		result.Source = livekit.TrackSource_SCREEN_SHARE
+	}
+
+	options := !video.Codec.IsNull() || !video.FrameRate.IsNull() || len(layers) > 0
+
+	switch {
+	case !video.Preset.IsNull() && options:
+		diags.AddAttributeError(path.Root("video").AtName("preset"), "Conflicting video encoding",
+			"Only one of preset and the codec, frame_rate and layer options can be set.")
+		return nil
+	case !video.Preset.IsNull():
+		result.EncodingOptions = &livekit.IngressVideoOptions_Preset{
+			Preset: livekit.IngressVideoEncodingPreset(livekit.IngressVideoEncodingPreset_value[video.Preset.ValueString()]),
+		}
+	case options:
+		encoding := &livekit.IngressVideoEncodingOptions{
+			FrameRate: video.FrameRate.ValueFloat64(),
+		}
+		if !video.Codec.IsNull() {
+			encoding.VideoCodec = livekit.VideoCodec(livekit.VideoCodec_value[strings.ToUpper(video.Codec.ValueString())])
+		}
+
+		qualities := map[string]bool{}
+		for i, layer := range layers {
+			if qualities[layer.Quality.ValueString()] {
+				diags.AddAttributeError(path.Root("video").AtName("layer").AtListIndex(i).AtName("quality"), "Duplicate video layer",
+					fmt.Sprintf("Only one layer can have quality %s.", layer.Quality.ValueString()))
+				return nil
+			}
+			qualities[layer.Quality.ValueString()] = true
+
+			encoding.Layers = append(encoding.Layers, &livekit.VideoLayer{
+				Quality: livekit.VideoQuality(livekit.VideoQuality_value[strings.ToUpper(layer.Quality.ValueString())]),
+				Width:   uint32(layer.Width.ValueInt64()),
+				Height:  uint32(layer.Height.ValueInt64()),
+				Bitrate: uint32(layer.Bitrate.ValueInt64()),
+			})
+		}
+
+		result.EncodingOptions = &livekit.IngressVideoOptions_Options{
+			Options: encoding,
+		}
+	}
+
+	return result
+}
+
+// refreshIngressAudio returns the audio block value with the options reported
+// by the server. Settings that are not configured stay unset while the server
+// reports their default.
+func refreshIngressAudio(ctx context.Context, value types.Object, info *livekit.IngressAudioOptions, diags *diag.Diagnostics) types.Object {
+	if value.IsNull() || value.IsUnknown() {
+		return value
+	}
+	if info == nil {
+		return types.ObjectNull(value.AttributeTypes(ctx))
+	}
+
+	var audio IngressAudioModel
+	diags.Append(value.As(ctx, &audio, basetypes.ObjectAsOptions{})...)
+
+	if diags.HasError() {
+		return value
+	}
+
+	if !audio.Name.IsNull() || info.Name != "" {
+		audio.Name = types.StringValue(info.Name)
+	}
+	if info.Source != livekit.TrackSource_UNKNOWN && (!audio.Source.IsNull() || info.Source != livekit.TrackSource_MICROPHONE) {
+		audio.Source = types.StringValue(strings.ToLower(info.Source.String()))
+	}
+
+	switch encoding := info.EncodingOptions.(type) {
+	case *livekit.IngressAudioOptions_Preset:
+		audio.Preset = types.StringValue(encoding.Preset.String())
+		audio.Codec = types.StringNull()
+		audio.Bitrate = types.Int64Null()
+		audio.Channels = types.Int64Null()
+		audio.DisableDtx = types.BoolNull()
+	case *livekit.IngressAudioOptions_Options:
+		options := encoding.Options
+		audio.Preset = types.StringNull()
+		// opus is the default and only supported codec.
+		if options.AudioCodec != livekit.AudioCodec_DEFAULT_AC && options.AudioCodec != livekit.AudioCodec_OPUS {
+			audio.Codec = types.StringValue(strings.ToLower(options.AudioCodec.String()))
+		}
+		if !audio.Bitrate.IsNull() || options.Bitrate != 0 {
+			audio.Bitrate = types.Int64Value(int64(options.Bitrate))
+		}
+		if !audio.Channels.IsNull() || options.Channels != 0 {
+			audio.Channels = types.Int64Value(int64(options.Channels))
+		}
+		if !audio.DisableDtx.IsNull() || options.DisableDtx {
+			audio.DisableDtx = types.BoolValue(options.DisableDtx)
+		}
+	default:
+		audio.Preset = types.StringNull()
+		audio.Codec = types.StringNull()
+		audio.Bitrate = types.Int64Null()
+		audio.Channels = types.Int64Null()
+		audio.DisableDtx = types.BoolNull()
+	}
+
+	result, d := types.ObjectValueFrom(ctx, value.AttributeTypes(ctx), audio)
+	diags.Append(d...)
+	return result
+}
+
+// refreshIngressVideo returns the video block value with the options reported
+// by the server. Settings that are not configured stay unset while the server
+// reports their default.
+func refreshIngressVideo(ctx context.Context, value types.Object, info *livekit.IngressVideoOptions, diags *diag.Diagnostics) types.Object {
+	if value.IsNull() || value.IsUnknown() {
+		return value
+	}
+	if info == nil {
+		return types.ObjectNull(value.AttributeTypes(ctx))
+	}
+
+	var video IngressVideoModel
+	diags.Append(value.As(ctx, &video, basetypes.ObjectAsOptions{})...)
+
+	if diags.HasError() {
+		return value
+	}
+
+	if !video.Name.IsNull() || info.Name != "" {
+		video.Name = types.StringValue(info.Name)
+	}
+	if info.Source != livekit.TrackSource_UNKNOWN && (!video.Source.IsNull() || info.Source != livekit.TrackSource_CAMERA) {
+		video.Source = types.StringValue(strings.ToLower(info.Source.String()))
+	}
+
+	layerType := video.Layers.ElementType(ctx)
+	var layers []IngressVideoLayerModel
+
+	switch encoding := info.EncodingOptions.(type) {
+	case *livekit.IngressVideoOptions_Preset:
+		video.Preset = types.StringValue(encoding.Preset.String())
+		video.Codec = types.StringNull()
+		video.FrameRate = types.Float64Null()
+	case *livekit.IngressVideoOptions_Options:
+		options := encoding.Options
+		video.Preset = types.StringNull()
+		if !video.Codec.IsNull() || options.VideoCodec != livekit.VideoCodec_DEFAULT_VC {
+			video.Codec = types.StringValue(strings.ToLower(options.VideoCodec.String()))
+		}
+		if !video.FrameRate.IsNull() || options.FrameRate != 0 {
+			video.FrameRate = types.Float64Value(options.FrameRate)
+		}
+		for _, layer := range options.Layers {
+			layers = append(layers, IngressVideoLayerModel{
+				Quality: types.StringValue(strings.ToLower(layer.Quality.String())),
+				Width:   types.Int64Value(int64(layer.Width)),
+				Height:  types.Int64Value(int64(layer.Height)),
+				Bitrate: types.Int64Value(int64(layer.Bitrate)),
+			})
+		}
+	default:
+		video.Preset = types.StringNull()
+		video.Codec = types.StringNull()
+		video.FrameRate = types.Float64Null()
+	}
+
+	// An empty list stands for a video block without layer blocks.
+	if len(layers) > 0 || len(video.Layers.Elements()) > 0 {
+		if layers == nil {
+			layers = []IngressVideoLayerModel{}
+		}
+		list, d := types.ListValueFrom(ctx, layerType, layers)
+		diags.Append(d...)
+		video.Layers = list
+	}
+
+	result, d := types.ObjectValueFrom(ctx, value.AttributeTypes(ctx), video)
+	diags.Append(d...)
+	return result
+}
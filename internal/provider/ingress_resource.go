@@ -0,0 +1,528 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+)
+
+var _ resource.Resource = &IngressResource{}
+var _ resource.ResourceWithModifyPlan = &IngressResource{}
+var _ resource.ResourceWithImportState = &IngressResource{}
+
+func NewIngressResource() resource.Resource {
+	return &IngressResource{}
+}
+
+// IngressResource defines the resource implementation.
+type IngressResource struct {
+	client *LivekitClient
+}
+
+// IngressResourceModel describes the resource data model.
+type IngressResourceModel struct {
+	Id                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	Endpoint            types.String `tfsdk:"endpoint"`
+	KeyId               types.String `tfsdk:"key_id"`
+	InputType           types.String `tfsdk:"input_type"`
+	Room                types.String `tfsdk:"room"`
+	ParticipantIdentity types.String `tfsdk:"participant_identity"`
+	ParticipantName     types.String `tfsdk:"participant_name"`
+	ParticipantMetadata types.String `tfsdk:"participant_metadata"`
+
+	EnableTranscoding types.Bool `tfsdk:"enable_transcoding"`
+	BypassTranscoding types.Bool `tfsdk:"bypass_transcoding"`
+
+	Audio types.Object `tfsdk:"audio"`
+	Video types.Object `tfsdk:"video"`
+
+	Url       types.String `tfsdk:"url"`
+	StreamKey types.String `tfsdk:"stream_key"`
+}
+
+// ingressInputTypes maps the input_type values to the API input types.
+var ingressInputTypes = map[string]livekit.IngressInput{
+	"rtmp": livekit.IngressInput_RTMP_INPUT,
+	"whip": livekit.IngressInput_WHIP_INPUT,
+}
+
+func (r *IngressResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ingress"
+}
+
+func (r *IngressResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Livekit ingress to push a stream into a room",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, the server assigned id of the ingress",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the ingress, e.g. to tell ingresses apart in the Livekit dashboard",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Livekit server url to manage the ingress on instead of the provider url",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "API key to sign the server API calls with, one of the keys configured on the provider. Defaults to the provider api_key",
+				Optional:            true,
+			},
+			"input_type": schema.StringAttribute{
+				MarkdownDescription: "Protocol the stream is pushed with: rtmp or whip. Defaults to rtmp",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("rtmp"),
+				Validators: []validator.String{
+					stringOneOf("rtmp", "whip"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"room": schema.StringAttribute{
+				MarkdownDescription: "Name of the room the stream is published to, prefixed with the provider room_name_prefix on the server",
+				Required:            true,
+				Validators: []validator.String{
+					roomName(),
+				},
+			},
+			"participant_identity": schema.StringAttribute{
+				MarkdownDescription: "Identity of the participant publishing the stream in the room",
+				Required:            true,
+				Validators: []validator.String{
+					participantIdentity(),
+				},
+			},
+			"participant_name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the participant publishing the stream",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"participant_metadata": schema.StringAttribute{
+				MarkdownDescription: "Metadata of the participant publishing the stream, e.g. JSON for the application",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enable_transcoding": schema.BoolAttribute{
+				MarkdownDescription: "Transcode the stream into the encoding options. Set it to false to forward a WHIP stream as it is, with lower latency. Defaults to true for rtmp and false for whip",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bypass_transcoding": schema.BoolAttribute{
+				MarkdownDescription: "Forward a WHIP stream as it is. Conflicts with enable_transcoding",
+				DeprecationMessage:  "Use enable_transcoding instead, bypass_transcoding = true is enable_transcoding = false.",
+				Optional:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "Url to push the stream to, e.g. the server setting of OBS",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"stream_key": schema.StringAttribute{
+				MarkdownDescription: "Stream key to push the stream with",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"audio": ingressAudioBlock(),
+			"video": ingressVideoBlock(),
+		},
+	}
+}
+
+func (r *IngressResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*LivekitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *LivekitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IngressResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data IngressResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Report conflicting encoding options before the ingress is created.
+	ingressAudio(ctx, data.Audio, &resp.Diagnostics)
+	ingressVideo(ctx, data.Video, &resp.Diagnostics)
+
+	var enableTranscoding, bypassTranscoding types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("enable_transcoding"), &enableTranscoding)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("bypass_transcoding"), &bypassTranscoding)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !enableTranscoding.IsNull() && !bypassTranscoding.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("bypass_transcoding"), "Conflicting attributes",
+			"Only one of enable_transcoding and bypass_transcoding can be set.")
+		return
+	}
+
+	// Streams that are forwarded as they are keep their encoding.
+	transcoding := !(enableTranscoding.Equal(types.BoolValue(false)) || bypassTranscoding.Equal(types.BoolValue(true)))
+	if !transcoding && (!data.Audio.IsNull() || !data.Video.IsNull()) {
+		resp.Diagnostics.AddAttributeError(path.Root("enable_transcoding"), "Invalid transcoding configuration",
+			"The audio and video encoding options need transcoding, they cannot be set when transcoding is disabled.")
+		return
+	}
+
+	// enable_transcoding follows the deprecated attribute while it is used.
+	if !bypassTranscoding.IsNull() && !bypassTranscoding.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("enable_transcoding"), !bypassTranscoding.ValueBool())...)
+	}
+}
+
+// serverClient returns the client for the endpoint of the ingress.
+func (r *IngressResource) serverClient(data *IngressResourceModel) (*LivekitClient, error) {
+	client, err := r.client.ForEndpoint(data.Endpoint.ValueString(), data.KeyId.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.RequireServer(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// setIngressAttributes copies the settings of info as reported by the server
+// into data.
+func setIngressAttributes(client *LivekitClient, data *IngressResourceModel, info *livekit.IngressInfo) {
+	data.Id = types.StringValue(info.IngressId)
+	data.Name = types.StringValue(info.Name)
+	data.Room = types.StringValue(client.ConfiguredRoomName(info.RoomName))
+	data.ParticipantIdentity = types.StringValue(info.ParticipantIdentity)
+	data.ParticipantName = types.StringValue(info.ParticipantName)
+	data.ParticipantMetadata = types.StringValue(info.ParticipantMetadata)
+	data.Url = types.StringValue(info.Url)
+	data.StreamKey = types.StringValue(info.StreamKey)
+
+	// Ingresses created before enable_transcoding only report the bypass.
+	if info.EnableTranscoding != nil {
+		data.EnableTranscoding = types.BoolValue(*info.EnableTranscoding)
+	} else {
+		data.EnableTranscoding = types.BoolValue(!info.BypassTranscoding)
+	}
+	if !data.BypassTranscoding.IsNull() {
+		data.BypassTranscoding = types.BoolValue(info.BypassTranscoding)
+	}
+
+	for inputType, input := range ingressInputTypes {
+		if input == info.InputType {
+			data.InputType = types.StringValue(inputType)
+		}
+	}
+}
+
+func (r *IngressResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IngressResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot create ingress", err.Error())
+		return
+	}
+
+	audio := ingressAudio(ctx, data.Audio, &resp.Diagnostics)
+	video := ingressVideo(ctx, data.Video, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{IngressAdmin: true})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	request := &livekit.CreateIngressRequest{
+		InputType:           ingressInputTypes[data.InputType.ValueString()],
+		Name:                data.Name.ValueString(),
+		RoomName:            client.RoomName(data.Room.ValueString()),
+		ParticipantIdentity: data.ParticipantIdentity.ValueString(),
+		ParticipantName:     data.ParticipantName.ValueString(),
+		ParticipantMetadata: data.ParticipantMetadata.ValueString(),
+		BypassTranscoding:   data.BypassTranscoding.ValueBool(),
+		Audio:               audio,
+		Video:               video,
+	}
+	if data.BypassTranscoding.IsNull() && !data.EnableTranscoding.IsUnknown() {
+		enableTranscoding := data.EnableTranscoding.ValueBool()
+		request.EnableTranscoding = &enableTranscoding
+	}
+
+	info, err := client.IngressService.CreateIngress(authCtx, request)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating ingress",
+			fmt.Sprintf("Could not create ingress for room %q: %s", client.RoomName(data.Room.ValueString()), err))
+		return
+	}
+
+	setIngressAttributes(client, &data, info)
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findIngress returns the ingress with id ingressId on the server of client,
+// or nil when there is no such ingress.
+func findIngress(ctx context.Context, client *LivekitClient, ingressId string) (*livekit.IngressInfo, error) {
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{IngressAdmin: true})
+	if err != nil {
+		return nil, fmt.Errorf("error creating API token: %w", err)
+	}
+
+	response, err := client.IngressService.ListIngress(authCtx, &livekit.ListIngressRequest{
+		IngressId: ingressId,
+	})
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not list ingress %q: %w", ingressId, err)
+	}
+
+	for _, info := range response.Items {
+		if info.IngressId == ingressId {
+			return info, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *IngressResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data IngressResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot read ingress", err.Error())
+		return
+	}
+
+	info, err := findIngress(ctx, client, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading ingress", err.Error())
+		return
+	}
+
+	// Ingresses deleted outside of Terraform are created again.
+	if info == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setIngressAttributes(client, &data, info)
+
+	// Only configured blocks are refreshed, the server reports defaults for
+	// the others.
+	data.Audio = refreshIngressAudio(ctx, data.Audio, info.Audio, &resp.Diagnostics)
+	data.Video = refreshIngressVideo(ctx, data.Video, info.Video, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IngressResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state IngressResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A new key_id only signs later API calls. The ingress is left alone, as
+	// the server rejects updates while a stream is pushed.
+	state.KeyId = data.KeyId
+	if reflect.DeepEqual(data, state) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot update ingress", err.Error())
+		return
+	}
+
+	audio := ingressAudio(ctx, data.Audio, &resp.Diagnostics)
+	video := ingressVideo(ctx, data.Video, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{IngressAdmin: true})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	// The url and stream key stay the same. The server only updates an
+	// ingress that no stream is pushed to.
+	request := &livekit.UpdateIngressRequest{
+		IngressId:           data.Id.ValueString(),
+		Name:                data.Name.ValueString(),
+		RoomName:            client.RoomName(data.Room.ValueString()),
+		ParticipantIdentity: data.ParticipantIdentity.ValueString(),
+		ParticipantName:     data.ParticipantName.ValueString(),
+		ParticipantMetadata: data.ParticipantMetadata.ValueString(),
+		Audio:               audio,
+		Video:               video,
+	}
+	if data.BypassTranscoding.IsNull() {
+		enableTranscoding := data.EnableTranscoding.ValueBool()
+		request.EnableTranscoding = &enableTranscoding
+	} else {
+		bypassTranscoding := data.BypassTranscoding.ValueBool()
+		request.BypassTranscoding = &bypassTranscoding
+	}
+
+	info, err := client.IngressService.UpdateIngress(authCtx, request)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating ingress",
+			fmt.Sprintf("Could not update ingress %q: %s", data.Id.ValueString(), err))
+		return
+	}
+
+	setIngressAttributes(client, &data, info)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IngressResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data IngressResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot delete ingress", err.Error())
+		return
+	}
+
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{IngressAdmin: true})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	_, err = client.IngressService.DeleteIngress(authCtx, &livekit.DeleteIngressRequest{
+		IngressId: data.Id.ValueString(),
+	})
+	// An ingress that is already gone is as good as deleted.
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting ingress", fmt.Sprintf("Could not delete ingress %q: %s", data.Id.ValueString(), err))
+		return
+	}
+}
+
+func (r *IngressResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !strings.HasPrefix(req.ID, "IN_") {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("The import ID must be the id of the ingress, e.g. IN_3fT9pWx2, got %q.", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// sensitiveJsonField matches JSON fields holding tokens, secrets, stream keys
+// or storage credentials.
+var sensitiveJsonField = regexp.MustCompile(`(?i)"(\w*(?:token|secret|password|stream_key|credentials|account_key)\w*)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+
+// sensitiveJsonList matches JSON fields holding lists of stream urls, which
+// usually embed the stream key.
+var sensitiveJsonList = regexp.MustCompile(`"(urls)"\s*:\s*\[(?:[^\]"]|"(?:[^"\\]|\\.)*")*\]`)
+
+type callInfoKey struct{}
+
+// callInfo carries HTTP level details of an API call from loggingTransport
+// back to loggingInterceptor.
+type callInfo struct {
+	statusCode int
+	requestId  string
+}
+
+// loggingInterceptor logs every attempt of an API call. Method, status and
+// duration are logged at debug level, the redacted messages at trace level.
+func loggingInterceptor() twirp.Interceptor {
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			service, _ := twirp.ServiceName(ctx)
+			method, _ := twirp.MethodName(ctx)
+
+			info := &callInfo{}
+			ctx = context.WithValue(ctx, callInfoKey{}, info)
+
+			fields := map[string]interface{}{
+				"method": service + "/" + method,
+			}
+
+			tflog.Trace(ctx, "Livekit API request", map[string]interface{}{
+				"method":  fields["method"],
+				"request": redactMessage(req),
+			})
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			fields["duration_ms"] = time.Since(start).Milliseconds()
+			fields["status_code"] = info.statusCode
+			if info.requestId != "" {
+				fields["request_id"] = info.requestId
+			}
+
+			if err != nil {
+				fields["error"] = err.Error()
+				tflog.Debug(ctx, "Livekit API call failed", fields)
+				return resp, err
+			}
+
+			tflog.Debug(ctx, "Livekit API call", fields)
+			tflog.Trace(ctx, "Livekit API response", map[string]interface{}{
+				"method":   fields["method"],
+				"response": redactMessage(resp),
+			})
+
+			return resp, err
+		}
+	}
+}
+
+// redactMessage renders an API message as JSON with all sensitive fields masked.
+func redactMessage(message interface{}) string {
+	m, ok := message.(proto.Message)
+	if !ok {
+		return ""
+	}
+
+	content, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return ""
+	}
+
+	redacted := sensitiveJsonField.ReplaceAllString(string(content), `"$1":"***"`)
+	return sensitiveJsonList.ReplaceAllString(redacted, `"$1":["***"]`)
+}
+
+// loggingTransport records the status and request id of responses for loggingInterceptor.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+
+	if info, ok := req.Context().Value(callInfoKey{}).(*callInfo); ok && resp != nil {
+		info.statusCode = resp.StatusCode
+		info.requestId = resp.Header.Get("X-Request-Id")
+	}
+
+	return resp, err
+}
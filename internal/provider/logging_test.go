@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+func TestRedactMessageEgress(t *testing.T) {
+	req := &livekit.CreateRoomRequest{
+		Name: "town-hall",
+		Egress: &livekit.RoomEgress{
+			Room: &livekit.RoomCompositeEgressRequest{
+				FileOutputs: []*livekit.EncodedFileOutput{
+					{
+						Filepath: "recordings/{room_name}.mp4",
+						Output: &livekit.EncodedFileOutput_Gcp{Gcp: &livekit.GCPUpload{
+							Credentials: `{"type":"service_account","private_key":"gcp-private-key"}`,
+							Bucket:      "recordings",
+						}},
+					},
+					{
+						Output: &livekit.EncodedFileOutput_Azure{Azure: &livekit.AzureBlobUpload{
+							AccountName:   "recordings",
+							AccountKey:    "azure-account-key",
+							ContainerName: "town-hall",
+						}},
+					},
+					{
+						Output: &livekit.EncodedFileOutput_S3{S3: &livekit.S3Upload{
+							AccessKey: "s3-access-key",
+							Secret:    "s3-secret",
+							Bucket:    "recordings",
+						}},
+					},
+				},
+				StreamOutputs: []*livekit.StreamOutput{{
+					Protocol: livekit.StreamProtocol_RTMP,
+					Urls:     []string{"rtmp://a.rtmp.youtube.com/live2/youtube-stream-key", "rtmp://live.twitch.tv/app/twitch-stream-key"},
+				}},
+			},
+		},
+	}
+
+	redacted := redactMessage(req)
+
+	for _, value := range []string{"gcp-private-key", "azure-account-key", "s3-secret", "youtube-stream-key", "twitch-stream-key"} {
+		if strings.Contains(redacted, value) {
+			t.Errorf("redacted message contains %q: %s", value, redacted)
+		}
+	}
+	// protojson randomizes the whitespace between fields, so untouched fields
+	// are only checked for their values.
+	for _, value := range []string{`"credentials":"***"`, `"account_key":"***"`, `"secret":"***"`, `"urls":["***"]`, "s3-access-key", "town-hall"} {
+		if !strings.Contains(redacted, value) {
+			t.Errorf("redacted message does not contain %q: %s", value, redacted)
+		}
+	}
+}
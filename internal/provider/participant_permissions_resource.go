@@ -0,0 +1,402 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+)
+
+var _ resource.Resource = &ParticipantPermissionsResource{}
+var _ resource.ResourceWithImportState = &ParticipantPermissionsResource{}
+
+func NewParticipantPermissionsResource() resource.Resource {
+	return &ParticipantPermissionsResource{}
+}
+
+// ParticipantPermissionsResource defines the resource implementation.
+type ParticipantPermissionsResource struct {
+	client *LivekitClient
+}
+
+// ParticipantPermissionsResourceModel describes the resource data model.
+type ParticipantPermissionsResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	Room              types.String `tfsdk:"room"`
+	Identity          types.String `tfsdk:"identity"`
+	Endpoint          types.String `tfsdk:"endpoint"`
+	KeyId             types.String `tfsdk:"key_id"`
+	CanSubscribe      types.Bool   `tfsdk:"can_subscribe"`
+	CanPublish        types.Bool   `tfsdk:"can_publish"`
+	CanPublishData    types.Bool   `tfsdk:"can_publish_data"`
+	CanPublishSources types.List   `tfsdk:"can_publish_sources"`
+	CanUpdateMetadata types.Bool   `tfsdk:"can_update_metadata"`
+	Hidden            types.Bool   `tfsdk:"hidden"`
+	Metadata          types.String `tfsdk:"metadata"`
+	Attributes        types.Map    `tfsdk:"attributes"`
+}
+
+func (r *ParticipantPermissionsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_participant_permissions"
+}
+
+func (r *ParticipantPermissionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Permissions of a participant in a Livekit room",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, the room name and identity separated by a slash",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"room": schema.StringAttribute{
+				MarkdownDescription: "Name of the room, prefixed with the provider room_name_prefix on the server",
+				Required:            true,
+				Validators: []validator.String{
+					roomName(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"identity": schema.StringAttribute{
+				MarkdownDescription: "Identity of the participant",
+				Required:            true,
+				Validators: []validator.String{
+					participantIdentity(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Livekit server url of the room instead of the provider url",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "API key to sign the server API calls with, one of the keys configured on the provider. Defaults to the provider api_key",
+				Optional:            true,
+			},
+			"can_subscribe": schema.BoolAttribute{
+				MarkdownDescription: "Allows subscribing to tracks. Defaults to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"can_publish": schema.BoolAttribute{
+				MarkdownDescription: "Allows publishing tracks. Defaults to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"can_publish_data": schema.BoolAttribute{
+				MarkdownDescription: "Allows publishing data messages. Defaults to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"can_publish_sources": schema.ListAttribute{
+				MarkdownDescription: "Restrict publishing to these track sources: camera, microphone, screen_share, screen_share_audio. All sources are allowed when omitted",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listElementsOneOf("camera", "microphone", "screen_share", "screen_share_audio"),
+				},
+			},
+			"can_update_metadata": schema.BoolAttribute{
+				MarkdownDescription: "Allows the participant to update its own name, metadata and attributes. Defaults to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"hidden": schema.BoolAttribute{
+				MarkdownDescription: "Hides the participant from other participants. Defaults to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"metadata": schema.StringAttribute{
+				MarkdownDescription: "Metadata of the participant. Left as is when omitted",
+				Optional:            true,
+			},
+			"attributes": schema.MapAttribute{
+				MarkdownDescription: "Attributes of the participant. Attributes that are not listed are left as is",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *ParticipantPermissionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*LivekitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *LivekitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// serverClient returns the client for the endpoint of the room.
+func (r *ParticipantPermissionsResource) serverClient(data *ParticipantPermissionsResourceModel) (*LivekitClient, error) {
+	client, err := r.client.ForEndpoint(data.Endpoint.ValueString(), data.KeyId.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.RequireServer(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (r *ParticipantPermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ParticipantPermissionsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.updateParticipant(ctx, &data, nil, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(data.Room.ValueString() + "/" + data.Identity.ValueString())
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// updateParticipant applies the permissions, metadata and attributes of data
+// to the participant. Attributes of previous that data no longer lists are
+// removed.
+func (r *ParticipantPermissionsResource) updateParticipant(ctx context.Context, data *ParticipantPermissionsResourceModel, previous map[string]string, diags *diag.Diagnostics) {
+	client, err := r.serverClient(data)
+	if err != nil {
+		diags.AddAttributeError(clientErrorPath(err), "Cannot update participant", err.Error())
+		return
+	}
+
+	var sources []string
+	diags.Append(data.CanPublishSources.ElementsAs(ctx, &sources, false)...)
+
+	attributes := map[string]string{}
+	diags.Append(data.Attributes.ElementsAs(ctx, &attributes, false)...)
+
+	if diags.HasError() {
+		return
+	}
+
+	// An empty value deletes an attribute.
+	for key := range previous {
+		if _, ok := attributes[key]; !ok {
+			attributes[key] = ""
+		}
+	}
+
+	permission := &livekit.ParticipantPermission{
+		CanSubscribe:      data.CanSubscribe.ValueBool(),
+		CanPublish:        data.CanPublish.ValueBool(),
+		CanPublishData:    data.CanPublishData.ValueBool(),
+		CanUpdateMetadata: data.CanUpdateMetadata.ValueBool(),
+		Hidden:            data.Hidden.ValueBool(),
+	}
+	for _, source := range sources {
+		permission.CanPublishSources = append(permission.CanPublishSources,
+			livekit.TrackSource(livekit.TrackSource_value[strings.ToUpper(source)]))
+	}
+
+	roomName := client.RoomName(data.Room.ValueString())
+
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomAdmin: true, Room: roomName})
+	if err != nil {
+		diags.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	// An empty metadata leaves the metadata as is.
+	_, err = client.RoomService.UpdateParticipant(authCtx, &livekit.UpdateParticipantRequest{
+		Room:       roomName,
+		Identity:   data.Identity.ValueString(),
+		Metadata:   data.Metadata.ValueString(),
+		Permission: permission,
+		Attributes: attributes,
+	})
+	if err != nil {
+		diags.AddError("Error updating participant",
+			fmt.Sprintf("Could not update participant %q in room %q: %s", data.Identity.ValueString(), roomName, err))
+	}
+}
+
+func (r *ParticipantPermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ParticipantPermissionsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot read participant", err.Error())
+		return
+	}
+
+	participant, err := findParticipant(ctx, client, client.RoomName(data.Room.ValueString()), data.Identity.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading participant", err.Error())
+		return
+	}
+
+	// The permissions are applied again once the participant rejoins.
+	if participant == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if permission := participant.Permission; permission != nil {
+		data.CanSubscribe = types.BoolValue(permission.CanSubscribe)
+		data.CanPublish = types.BoolValue(permission.CanPublish)
+		data.CanPublishData = types.BoolValue(permission.CanPublishData)
+		data.CanUpdateMetadata = types.BoolValue(permission.CanUpdateMetadata)
+		data.Hidden = types.BoolValue(permission.Hidden)
+
+		if len(permission.CanPublishSources) > 0 || !data.CanPublishSources.IsNull() {
+			var sources []string
+			for _, source := range permission.CanPublishSources {
+				sources = append(sources, strings.ToLower(source.String()))
+			}
+
+			canPublishSources, diags := types.ListValueFrom(ctx, types.StringType, sources)
+			resp.Diagnostics.Append(diags...)
+			data.CanPublishSources = canPublishSources
+		}
+	}
+
+	// Only the metadata and attributes managed by the resource are refreshed.
+	if !data.Metadata.IsNull() {
+		data.Metadata = types.StringValue(participant.Metadata)
+	}
+
+	if !data.Attributes.IsNull() {
+		attributes := map[string]string{}
+		for key := range data.Attributes.Elements() {
+			if value, ok := participant.Attributes[key]; ok {
+				attributes[key] = value
+			}
+		}
+
+		attributesValue, diags := types.MapValueFrom(ctx, types.StringType, attributes)
+		resp.Diagnostics.Append(diags...)
+		data.Attributes = attributesValue
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findParticipant returns the participant with identity in the room named
+// roomName on the server of client, or nil when there is no such participant.
+func findParticipant(ctx context.Context, client *LivekitClient, roomName string, identity string) (*livekit.ParticipantInfo, error) {
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomAdmin: true, Room: roomName})
+	if err != nil {
+		return nil, fmt.Errorf("error creating API token: %w", err)
+	}
+
+	participant, err := client.RoomService.GetParticipant(authCtx, &livekit.RoomParticipantIdentity{
+		Room:     roomName,
+		Identity: identity,
+	})
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get participant %q in room %q: %w", identity, roomName, err)
+	}
+	return participant, nil
+}
+
+func (r *ParticipantPermissionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ParticipantPermissionsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous := map[string]string{}
+	resp.Diagnostics.Append(state.Attributes.ElementsAs(ctx, &previous, false)...)
+
+	r.updateParticipant(ctx, &data, previous, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ParticipantPermissionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The permissions of the participant are left as they are.
+}
+
+func (r *ParticipantPermissionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	room, identity, ok := strings.Cut(req.ID, "/")
+	if !ok || room == "" || identity == "" {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("The import ID must be the room name and identity separated by a slash, e.g. lobby/bot, got %q.", req.ID))
+		return
+	}
+
+	// Accept the name on the server as well, including the room_name_prefix.
+	room = r.client.ConfiguredRoomName(room)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), room+"/"+identity)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("room"), room)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("identity"), identity)...)
+}
@@ -0,0 +1,292 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+)
+
+var _ resource.Resource = &ParticipantSubscriptionsResource{}
+
+func NewParticipantSubscriptionsResource() resource.Resource {
+	return &ParticipantSubscriptionsResource{}
+}
+
+// ParticipantSubscriptionsResource defines the resource implementation.
+type ParticipantSubscriptionsResource struct {
+	client *LivekitClient
+}
+
+// ParticipantSubscriptionsResourceModel describes the resource data model.
+type ParticipantSubscriptionsResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Room      types.String `tfsdk:"room"`
+	Identity  types.String `tfsdk:"identity"`
+	Endpoint  types.String `tfsdk:"endpoint"`
+	KeyId     types.String `tfsdk:"key_id"`
+	TrackSids types.Set    `tfsdk:"track_sids"`
+}
+
+func (r *ParticipantSubscriptionsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_participant_subscriptions"
+}
+
+func (r *ParticipantSubscriptionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Track subscriptions of a participant in a Livekit room",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, the room name and identity separated by a slash",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"room": schema.StringAttribute{
+				MarkdownDescription: "Name of the room, prefixed with the provider room_name_prefix on the server",
+				Required:            true,
+				Validators: []validator.String{
+					roomName(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"identity": schema.StringAttribute{
+				MarkdownDescription: "Identity of the subscribing participant",
+				Required:            true,
+				Validators: []validator.String{
+					participantIdentity(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Livekit server url of the room instead of the provider url",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "API key to sign the server API calls with, one of the keys configured on the provider. Defaults to the provider api_key",
+				Optional:            true,
+			},
+			"track_sids": schema.SetAttribute{
+				MarkdownDescription: "Server assigned ids of the tracks to subscribe to",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *ParticipantSubscriptionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*LivekitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *LivekitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// serverClient returns the client for the endpoint of the room.
+func (r *ParticipantSubscriptionsResource) serverClient(data *ParticipantSubscriptionsResourceModel) (*LivekitClient, error) {
+	client, err := r.client.ForEndpoint(data.Endpoint.ValueString(), data.KeyId.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.RequireServer(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// updateSubscriptions subscribes the participant of data to trackSids, or
+// unsubscribes it when subscribe is false.
+func (r *ParticipantSubscriptionsResource) updateSubscriptions(ctx context.Context, data *ParticipantSubscriptionsResourceModel, trackSids []string, subscribe bool, diags *diag.Diagnostics) {
+	if len(trackSids) == 0 {
+		return
+	}
+
+	client, err := r.serverClient(data)
+	if err != nil {
+		diags.AddAttributeError(clientErrorPath(err), "Cannot update subscriptions", err.Error())
+		return
+	}
+
+	roomName := client.RoomName(data.Room.ValueString())
+
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomAdmin: true, Room: roomName})
+	if err != nil {
+		diags.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	_, err = client.RoomService.UpdateSubscriptions(authCtx, &livekit.UpdateSubscriptionsRequest{
+		Room:      roomName,
+		Identity:  data.Identity.ValueString(),
+		TrackSids: trackSids,
+		Subscribe: subscribe,
+	})
+	if err != nil {
+		diags.AddError("Error updating subscriptions",
+			fmt.Sprintf("Could not update the subscriptions of participant %q in room %q: %s", data.Identity.ValueString(), roomName, err))
+	}
+}
+
+func (r *ParticipantSubscriptionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ParticipantSubscriptionsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	var trackSids []string
+	resp.Diagnostics.Append(data.TrackSids.ElementsAs(ctx, &trackSids, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.updateSubscriptions(ctx, &data, trackSids, true, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(data.Room.ValueString() + "/" + data.Identity.ValueString())
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ParticipantSubscriptionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ParticipantSubscriptionsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot read participant", err.Error())
+		return
+	}
+
+	participant, err := findParticipant(ctx, client, client.RoomName(data.Room.ValueString()), data.Identity.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading participant", err.Error())
+		return
+	}
+
+	// The server does not report subscriptions, only a participant that left
+	// is detected. It is subscribed again once it rejoins.
+	if participant == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+}
+
+func (r *ParticipantSubscriptionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ParticipantSubscriptionsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	var planned, previous []string
+	resp.Diagnostics.Append(data.TrackSids.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(state.TrackSids.ElementsAs(ctx, &previous, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var added, removed []string
+	for _, trackSid := range planned {
+		if !slices.Contains(previous, trackSid) {
+			added = append(added, trackSid)
+		}
+	}
+	for _, trackSid := range previous {
+		if !slices.Contains(planned, trackSid) {
+			removed = append(removed, trackSid)
+		}
+	}
+
+	r.updateSubscriptions(ctx, &data, removed, false, &resp.Diagnostics)
+	r.updateSubscriptions(ctx, &data, added, true, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ParticipantSubscriptionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ParticipantSubscriptionsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	var trackSids []string
+	resp.Diagnostics.Append(data.TrackSids.ElementsAs(ctx, &trackSids, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot update subscriptions", err.Error())
+		return
+	}
+
+	participant, err := findParticipant(ctx, client, client.RoomName(data.Room.ValueString()), data.Identity.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading participant", err.Error())
+		return
+	}
+
+	// A participant that left has no subscriptions left to remove.
+	if participant == nil {
+		return
+	}
+
+	r.updateSubscriptions(ctx, &data, trackSids, false, &resp.Diagnostics)
+}
@@ -0,0 +1,362 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+)
+
+var _ datasource.DataSource = &ParticipantsDataSource{}
+
+func NewParticipantsDataSource() datasource.DataSource {
+	return &ParticipantsDataSource{}
+}
+
+// ParticipantsDataSource defines the data source implementation.
+type ParticipantsDataSource struct {
+	client *LivekitClient
+}
+
+// ParticipantsDataSourceModel describes the data source data model.
+type ParticipantsDataSourceModel struct {
+	Room         types.String `tfsdk:"room"`
+	Endpoint     types.String `tfsdk:"endpoint"`
+	KeyId        types.String `tfsdk:"key_id"`
+	Participants types.List   `tfsdk:"participants"`
+}
+
+// ParticipantsDataSourceParticipantModel describes a participant of the data
+// source data model.
+type ParticipantsDataSourceParticipantModel struct {
+	Identity    types.String `tfsdk:"identity"`
+	Sid         types.String `tfsdk:"sid"`
+	Name        types.String `tfsdk:"name"`
+	State       types.String `tfsdk:"state"`
+	Kind        types.String `tfsdk:"kind"`
+	JoinedAt    types.String `tfsdk:"joined_at"`
+	Metadata    types.String `tfsdk:"metadata"`
+	Attributes  types.Map    `tfsdk:"attributes"`
+	IsPublisher types.Bool   `tfsdk:"is_publisher"`
+	Permission  types.Object `tfsdk:"permission"`
+	Tracks      types.List   `tfsdk:"tracks"`
+}
+
+// ParticipantsDataSourcePermissionModel describes the permission of a
+// participant.
+type ParticipantsDataSourcePermissionModel struct {
+	CanSubscribe      types.Bool `tfsdk:"can_subscribe"`
+	CanPublish        types.Bool `tfsdk:"can_publish"`
+	CanPublishData    types.Bool `tfsdk:"can_publish_data"`
+	CanPublishSources types.List `tfsdk:"can_publish_sources"`
+	CanUpdateMetadata types.Bool `tfsdk:"can_update_metadata"`
+	Hidden            types.Bool `tfsdk:"hidden"`
+}
+
+// ParticipantsDataSourceTrackModel describes a published track of a
+// participant.
+type ParticipantsDataSourceTrackModel struct {
+	Sid    types.String `tfsdk:"sid"`
+	Name   types.String `tfsdk:"name"`
+	Type   types.String `tfsdk:"type"`
+	Source types.String `tfsdk:"source"`
+	Muted  types.Bool   `tfsdk:"muted"`
+}
+
+var participantsDataSourcePermissionAttributeTypes = map[string]attr.Type{
+	"can_subscribe":       types.BoolType,
+	"can_publish":         types.BoolType,
+	"can_publish_data":    types.BoolType,
+	"can_publish_sources": types.ListType{ElemType: types.StringType},
+	"can_update_metadata": types.BoolType,
+	"hidden":              types.BoolType,
+}
+
+var participantsDataSourceTrackAttributeTypes = map[string]attr.Type{
+	"sid":    types.StringType,
+	"name":   types.StringType,
+	"type":   types.StringType,
+	"source": types.StringType,
+	"muted":  types.BoolType,
+}
+
+var participantsDataSourceParticipantAttributeTypes = map[string]attr.Type{
+	"identity":     types.StringType,
+	"sid":          types.StringType,
+	"name":         types.StringType,
+	"state":        types.StringType,
+	"kind":         types.StringType,
+	"joined_at":    types.StringType,
+	"metadata":     types.StringType,
+	"attributes":   types.MapType{ElemType: types.StringType},
+	"is_publisher": types.BoolType,
+	"permission":   types.ObjectType{AttrTypes: participantsDataSourcePermissionAttributeTypes},
+	"tracks":       types.ListType{ElemType: types.ObjectType{AttrTypes: participantsDataSourceTrackAttributeTypes}},
+}
+
+func (d *ParticipantsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_participants"
+}
+
+func (d *ParticipantsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Participants connected to a Livekit room",
+
+		Attributes: map[string]schema.Attribute{
+			"room": schema.StringAttribute{
+				MarkdownDescription: "Name of the room, prefixed with the provider room_name_prefix on the server",
+				Required:            true,
+				Validators: []validator.String{
+					roomName(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Livekit server url of the room instead of the provider url",
+				Optional:            true,
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "API key to sign the server API calls with, one of the keys configured on the provider. Defaults to the provider api_key",
+				Optional:            true,
+			},
+			"participants": schema.ListNestedAttribute{
+				MarkdownDescription: "The participants, in the order they joined",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"identity": schema.StringAttribute{
+							MarkdownDescription: "Identity of the participant",
+							Computed:            true,
+						},
+						"sid": schema.StringAttribute{
+							MarkdownDescription: "Server assigned id of the participant",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Display name of the participant",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "Connection state: joining, joined, active or disconnected",
+							Computed:            true,
+						},
+						"kind": schema.StringAttribute{
+							MarkdownDescription: "Participant kind: standard, ingress, egress, sip or agent",
+							Computed:            true,
+						},
+						"joined_at": schema.StringAttribute{
+							MarkdownDescription: "Time the participant joined as an RFC3339 timestamp",
+							Computed:            true,
+						},
+						"metadata": schema.StringAttribute{
+							MarkdownDescription: "Metadata of the participant",
+							Computed:            true,
+						},
+						"attributes": schema.MapAttribute{
+							MarkdownDescription: "Attributes of the participant",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"is_publisher": schema.BoolAttribute{
+							MarkdownDescription: "Whether the participant publishes tracks",
+							Computed:            true,
+						},
+						"permission": schema.SingleNestedAttribute{
+							MarkdownDescription: "Permissions of the participant",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"can_subscribe": schema.BoolAttribute{
+									MarkdownDescription: "Allows subscribing to tracks",
+									Computed:            true,
+								},
+								"can_publish": schema.BoolAttribute{
+									MarkdownDescription: "Allows publishing tracks",
+									Computed:            true,
+								},
+								"can_publish_data": schema.BoolAttribute{
+									MarkdownDescription: "Allows publishing data messages",
+									Computed:            true,
+								},
+								"can_publish_sources": schema.ListAttribute{
+									MarkdownDescription: "Track sources the participant may publish, all when empty",
+									ElementType:         types.StringType,
+									Computed:            true,
+								},
+								"can_update_metadata": schema.BoolAttribute{
+									MarkdownDescription: "Allows updating its own name, metadata and attributes",
+									Computed:            true,
+								},
+								"hidden": schema.BoolAttribute{
+									MarkdownDescription: "Hidden from other participants",
+									Computed:            true,
+								},
+							},
+						},
+						"tracks": schema.ListNestedAttribute{
+							MarkdownDescription: "Tracks published by the participant",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"sid": schema.StringAttribute{
+										MarkdownDescription: "Server assigned id of the track",
+										Computed:            true,
+									},
+									"name": schema.StringAttribute{
+										MarkdownDescription: "Name of the track",
+										Computed:            true,
+									},
+									"type": schema.StringAttribute{
+										MarkdownDescription: "Track type: audio, video or data",
+										Computed:            true,
+									},
+									"source": schema.StringAttribute{
+										MarkdownDescription: "Track source: camera, microphone, screen_share, screen_share_audio or unknown",
+										Computed:            true,
+									},
+									"muted": schema.BoolAttribute{
+										MarkdownDescription: "Whether the track is muted",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ParticipantsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*LivekitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *LivekitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ParticipantsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ParticipantsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client.ForEndpoint(data.Endpoint.ValueString(), data.KeyId.ValueString())
+	if err == nil {
+		err = client.RequireServer()
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot list participants", err.Error())
+		return
+	}
+
+	roomName := client.RoomName(data.Room.ValueString())
+
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomAdmin: true, Room: roomName})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	response, err := client.RoomService.ListParticipants(authCtx, &livekit.ListParticipantsRequest{
+		Room: roomName,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing participants", fmt.Sprintf("Could not list participants of room %q: %s", roomName, err))
+		return
+	}
+
+	participants := []ParticipantsDataSourceParticipantModel{}
+	for _, participant := range response.Participants {
+		participants = append(participants, participantModel(ctx, participant, &resp.Diagnostics))
+	}
+
+	participantsValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: participantsDataSourceParticipantAttributeTypes}, participants)
+	resp.Diagnostics.Append(diags...)
+	data.Participants = participantsValue
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// participantModel converts a participant as reported by the server.
+func participantModel(ctx context.Context, participant *livekit.ParticipantInfo, diags *diag.Diagnostics) ParticipantsDataSourceParticipantModel {
+	attributes, d := types.MapValueFrom(ctx, types.StringType, participant.Attributes)
+	diags.Append(d...)
+
+	permission := types.ObjectNull(participantsDataSourcePermissionAttributeTypes)
+	if participant.Permission != nil {
+		sources := []string{}
+		for _, source := range participant.Permission.CanPublishSources {
+			sources = append(sources, strings.ToLower(source.String()))
+		}
+
+		canPublishSources, d := types.ListValueFrom(ctx, types.StringType, sources)
+		diags.Append(d...)
+
+		permission, d = types.ObjectValueFrom(ctx, participantsDataSourcePermissionAttributeTypes, ParticipantsDataSourcePermissionModel{
+			CanSubscribe:      types.BoolValue(participant.Permission.CanSubscribe),
+			CanPublish:        types.BoolValue(participant.Permission.CanPublish),
+			CanPublishData:    types.BoolValue(participant.Permission.CanPublishData),
+			CanPublishSources: canPublishSources,
+			CanUpdateMetadata: types.BoolValue(participant.Permission.CanUpdateMetadata),
+			Hidden:            types.BoolValue(participant.Permission.Hidden),
+		})
+		diags.Append(d...)
+	}
+
+	tracks := []ParticipantsDataSourceTrackModel{}
+	for _, track := range participant.Tracks {
+		tracks = append(tracks, ParticipantsDataSourceTrackModel{
+			Sid:    types.StringValue(track.Sid),
+			Name:   types.StringValue(track.Name),
+			Type:   types.StringValue(strings.ToLower(track.Type.String())),
+			Source: types.StringValue(strings.ToLower(track.Source.String())),
+			Muted:  types.BoolValue(track.Muted),
+		})
+	}
+
+	tracksValue, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: participantsDataSourceTrackAttributeTypes}, tracks)
+	diags.Append(d...)
+
+	return ParticipantsDataSourceParticipantModel{
+		Identity:    types.StringValue(participant.Identity),
+		Sid:         types.StringValue(participant.Sid),
+		Name:        types.StringValue(participant.Name),
+		State:       types.StringValue(strings.ToLower(participant.State.String())),
+		Kind:        types.StringValue(strings.ToLower(participant.Kind.String())),
+		JoinedAt:    types.StringValue(formatTime(time.Unix(participant.JoinedAt, 0))),
+		Metadata:    types.StringValue(participant.Metadata),
+		Attributes:  attributes,
+		IsPublisher: types.BoolValue(participant.IsPublisher),
+		Permission:  permission,
+		Tracks:      tracksValue,
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultCredentialsFile returns the location of the shared credentials file.
+func defaultCredentialsFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to determine home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".livekit", "credentials"), nil
+}
+
+// loadProfile reads the named profile from an INI style credentials file:
+//
+//	[production]
+//	api_key    = APIxxxxxxxx
+//	api_secret = xxxxxxxx
+//	url        = wss://production.livekit.cloud
+func loadProfile(credentialsFile string, name string) (*CliProjectConfig, error) {
+	if credentialsFile == "" {
+		var err error
+		if credentialsFile, err = defaultCredentialsFile(); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading credentials file: %w", err)
+	}
+
+	var profile *CliProjectConfig
+	section := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == name && profile == nil {
+				profile = &CliProjectConfig{Name: name}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", credentialsFile, lineNumber)
+		}
+		if section != name {
+			continue
+		}
+
+		switch strings.TrimSpace(key) {
+		case "api_key":
+			profile.ApiKey = strings.TrimSpace(value)
+		case "api_secret":
+			profile.ApiSecret = strings.TrimSpace(value)
+		case "url":
+			profile.Url = strings.TrimSpace(value)
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown setting %q", credentialsFile, lineNumber, strings.TrimSpace(key))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading credentials file: %w", err)
+	}
+
+	if profile == nil {
+		return nil, fmt.Errorf("profile %q not found in %s", name, credentialsFile)
+	}
+
+	return profile, nil
+}
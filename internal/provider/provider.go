@@ -4,20 +4,36 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	neturl "net/url"
 	"os"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/livekit/protocol/auth"
 )
 
 var _ provider.Provider = &LivekitProvider{}
 var _ provider.ProviderWithFunctions = &LivekitProvider{}
 
+// Credentials and url of a livekit-server started with --dev.
+const (
+	devModeApiKey    = "devkey"
+	devModeApiSecret = "secret"
+	devModeUrl       = "ws://localhost:7880"
+)
+
 type LivekitProvider struct {
 	version string
 }
@@ -25,6 +41,67 @@ type LivekitProvider struct {
 type LivekitProviderModel struct {
 	ApiKey    types.String `tfsdk:"api_key"`
 	ApiSecret types.String `tfsdk:"api_secret"`
+	Url       types.String `tfsdk:"url"`
+	Keys      types.Map    `tfsdk:"keys"`
+
+	PreviousApiSecret types.String `tfsdk:"previous_api_secret"`
+
+	ApiKeyFile    types.String `tfsdk:"api_key_file"`
+	ApiSecretFile types.String `tfsdk:"api_secret_file"`
+
+	DefaultTokenTtl types.String `tfsdk:"default_token_ttl"`
+	RoomNamePrefix  types.String `tfsdk:"room_name_prefix"`
+
+	ConfigFile types.String `tfsdk:"config_file"`
+	Project    types.String `tfsdk:"project"`
+
+	CredentialsFile types.String `tfsdk:"credentials_file"`
+	Profile         types.String `tfsdk:"profile"`
+
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryMinBackoff types.String `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff types.String `tfsdk:"retry_max_backoff"`
+	RequestTimeout  types.String `tfsdk:"request_timeout"`
+
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+
+	CaCertPem          types.String `tfsdk:"ca_cert_pem"`
+	ClientCertPem      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPem       types.String `tfsdk:"client_key_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	DevMode             types.Bool   `tfsdk:"dev_mode"`
+	ValidateCredentials types.Bool   `tfsdk:"validate_credentials"`
+	UserAgentExtra      types.String `tfsdk:"user_agent_extra"`
+}
+
+// unknownAttributes returns the paths of connection and credential attributes
+// whose value is not known yet.
+func (m LivekitProviderModel) unknownAttributes() path.Paths {
+	values := []struct {
+		name  string
+		value attr.Value
+	}{
+		{"api_key", m.ApiKey},
+		{"api_secret", m.ApiSecret},
+		{"url", m.Url},
+		{"keys", m.Keys},
+		{"api_key_file", m.ApiKeyFile},
+		{"api_secret_file", m.ApiSecretFile},
+		{"config_file", m.ConfigFile},
+		{"project", m.Project},
+		{"credentials_file", m.CredentialsFile},
+		{"profile", m.Profile},
+	}
+
+	var unknown path.Paths
+	for _, v := range values {
+		if v.value.IsUnknown() {
+			unknown = append(unknown, path.Root(v.name))
+		}
+	}
+	return unknown
 }
 
 func (p *LivekitProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -40,7 +117,111 @@ func (p *LivekitProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:            true,
 			},
 			"api_secret": schema.StringAttribute{
-				MarkdownDescription: "Livekit API Secret. Can also be set via environment variable LIVEKIT_API_SECRET",
+				MarkdownDescription: "Livekit API Secret. Accepts ephemeral values. Can also be set via environment variable LIVEKIT_API_SECRET",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"previous_api_secret": schema.StringAttribute{
+				MarkdownDescription: "Secret of api_key before its rotation. New tokens are always signed with api_secret, the previous secret is only used to recognize existing tokens",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"keys": schema.MapAttribute{
+				MarkdownDescription: "Additional named API key pairs, mapping API key to API secret. Resources select one of them via their key_id attribute",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"api_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file containing the Livekit API Key. Conflicts with api_key",
+				Optional:            true,
+			},
+			"api_secret_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file containing the Livekit API Secret. Conflicts with api_secret",
+				Optional:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "Livekit server url, e.g. wss://my-project.livekit.cloud. Required by resources that call the server API. Can also be set via environment variable LIVEKIT_URL",
+				Optional:            true,
+			},
+			"default_token_ttl": schema.StringAttribute{
+				MarkdownDescription: "Validity duration of access tokens that do not set valid_for, e.g. 1h or 7d. Defaults to 1h",
+				Optional:            true,
+				Validators: []validator.String{
+					tokenDuration(),
+				},
+			},
+			"room_name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Prefix prepended to the room names of all resources and token grants, e.g. to separate environments sharing a project",
+				Optional:            true,
+			},
+			"config_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the Livekit CLI configuration file to read credentials from. Defaults to ~/.livekit/cli.yaml when project is set",
+				Optional:            true,
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Livekit CLI project to read api_key, api_secret and url from. Defaults to the default project of config_file when only config_file is set",
+				Optional:            true,
+			},
+			"credentials_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the shared credentials file holding named profiles. Defaults to ~/.livekit/credentials",
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Profile of credentials_file to read api_key, api_secret and url from. Can also be set via environment variable LIVEKIT_PROFILE. Conflicts with project",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of retries of a server API call failing with a transient error. Defaults to 3",
+				Optional:            true,
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				MarkdownDescription: "Wait before the first retry, doubled on every further retry. Defaults to 1s",
+				Optional:            true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				MarkdownDescription: "Upper bound of the wait between retries. Defaults to 30s",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout of a single server API call attempt, e.g. 30s. Calls are not bounded by default",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of server API calls in flight, shared by all resources and data sources. Unlimited by default",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of server API calls started per second. Unlimited by default",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates trusted in addition to the system roots when connecting to the server",
+				Optional:            true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS. Requires client_key_pem",
+				Optional:            true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of the client certificate. Requires client_cert_pem",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the server certificate. Only use this for testing",
+				Optional:            true,
+			},
+			"dev_mode": schema.BoolAttribute{
+				MarkdownDescription: "Target a local livekit-server started with --dev: defaults api_key, api_secret and url to devkey, secret and ws://localhost:7880 and skips TLS verification",
+				Optional:            true,
+			},
+			"user_agent_extra": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the User-Agent header of server API calls, e.g. to identify a pipeline",
+				Optional:            true,
+			},
+			"validate_credentials": schema.BoolAttribute{
+				MarkdownDescription: "Verify api_key and every entry of keys with an authenticated server API call while configuring the provider. Requires url",
 				Optional:            true,
 			},
 		},
@@ -56,8 +237,66 @@ func (p *LivekitProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	// Values only known after apply, e.g. outputs of another module, cannot be
+	// used yet. Defer all resources if Terraform supports it.
+	if unknown := data.unknownAttributes(); len(unknown) > 0 {
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &provider.Deferred{
+				Reason: provider.DeferredReasonProviderConfigUnknown,
+			}
+			return
+		}
+
+		for _, attribute := range unknown {
+			resp.Diagnostics.AddAttributeError(attribute, "Unknown Livekit provider configuration",
+				"The provider cannot be configured with a value that is only known after apply. "+
+					"Either apply the source of the value first, e.g. with -target, or use a Terraform version supporting deferred actions.")
+		}
+		return
+	}
+
 	apiKey := os.Getenv("LIVEKIT_API_KEY")
 	apiSecret := os.Getenv("LIVEKIT_API_SECRET")
+	url := os.Getenv("LIVEKIT_URL")
+
+	// A CLI project is selected explicitly, so it takes precedence over the
+	// environment but not over attributes set in the configuration.
+	if !data.ConfigFile.IsNull() || !data.Project.IsNull() {
+		project, err := loadCliProject(data.ConfigFile.ValueString(), data.Project.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("project"), "Unable to load Livekit CLI project", err.Error())
+			return
+		}
+
+		apiKey = valueOrDefault(project.ApiKey, apiKey)
+		apiSecret = valueOrDefault(project.ApiSecret, apiSecret)
+		url = valueOrDefault(project.Url, url)
+	}
+
+	// LIVEKIT_PROFILE is ignored when a CLI project is configured, while an
+	// explicit profile conflicts with it.
+	usesCliProject := !data.ConfigFile.IsNull() || !data.Project.IsNull()
+	if !data.Profile.IsNull() && usesCliProject {
+		resp.Diagnostics.AddAttributeError(path.Root("profile"), "Conflicting credential sources",
+			"Only one of profile and a Livekit CLI project can be used.")
+		return
+	}
+
+	profileName := os.Getenv("LIVEKIT_PROFILE")
+	if !data.Profile.IsNull() {
+		profileName = data.Profile.ValueString()
+	}
+	if profileName != "" && !usesCliProject {
+		profile, err := loadProfile(data.CredentialsFile.ValueString(), profileName)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("profile"), "Unable to load Livekit profile", err.Error())
+			return
+		}
+
+		apiKey = valueOrDefault(profile.ApiKey, apiKey)
+		apiSecret = valueOrDefault(profile.ApiSecret, apiSecret)
+		url = valueOrDefault(profile.Url, url)
+	}
 
 	if !data.ApiKey.IsNull() {
 		apiKey = data.ApiKey.ValueString()
@@ -65,15 +304,106 @@ func (p *LivekitProvider) Configure(ctx context.Context, req provider.ConfigureR
 	if !data.ApiSecret.IsNull() {
 		apiSecret = data.ApiSecret.ValueString()
 	}
+	if !data.ApiKeyFile.IsNull() {
+		if !data.ApiKey.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("api_key_file"), "Conflicting api key configuration",
+				"Only one of api_key and api_key_file can be set.")
+		}
+		apiKey = readCredentialFile(data.ApiKeyFile.ValueString(), path.Root("api_key_file"), &resp.Diagnostics)
+	}
+	if !data.ApiSecretFile.IsNull() {
+		if !data.ApiSecret.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("api_secret_file"), "Conflicting api secret configuration",
+				"Only one of api_secret and api_secret_file can be set.")
+		}
+		apiSecret = readCredentialFile(data.ApiSecretFile.ValueString(), path.Root("api_secret_file"), &resp.Diagnostics)
+	}
+	if !data.Url.IsNull() {
+		url = data.Url.ValueString()
+	}
+
+	// Dev mode only fills in what is not configured otherwise, matching the
+	// defaults of livekit-server --dev.
+	if data.DevMode.ValueBool() {
+		apiKey = valueOrDefault(apiKey, devModeApiKey)
+		apiSecret = valueOrDefault(apiSecret, devModeApiSecret)
+		url = valueOrDefault(url, devModeUrl)
+	}
+
+	if url != "" {
+		if err := validateServerUrl(url); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("url"), "Invalid Livekit server url",
+				"The url from the configuration, the Livekit CLI project or the LIVEKIT_URL environment variable is invalid: "+err.Error())
+		}
+	}
+
+	keys := make(map[string]string)
+	if !data.Keys.IsNull() {
+		resp.Diagnostics.Append(data.Keys.ElementsAs(ctx, &keys, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	for key, secret := range keys {
+		if key == "" || secret == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("keys"), "Livekit key pair invalid",
+				"Every entry of keys needs a non-empty API key and API secret.")
+		}
+	}
+
+	defaultTokenTtl := defaultValidFor
+	if !data.DefaultTokenTtl.IsNull() {
+		defaultTokenTtl = data.DefaultTokenTtl.ValueString()
+		if _, err := parseTokenDuration(defaultTokenTtl); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("default_token_ttl"), "Invalid default_token_ttl", err.Error())
+		}
+	}
+
+	maxRetries := defaultMaxRetries
+	if !data.MaxRetries.IsNull() {
+		maxRetries = int(data.MaxRetries.ValueInt64())
+		if maxRetries < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid max_retries",
+				"max_retries must not be negative.")
+		}
+	}
+
+	retryMinBackoff := parseDurationAttribute(data.RetryMinBackoff, path.Root("retry_min_backoff"), defaultRetryMinBackoff, &resp.Diagnostics)
+	retryMaxBackoff := parseDurationAttribute(data.RetryMaxBackoff, path.Root("retry_max_backoff"), defaultRetryMaxBackoff, &resp.Diagnostics)
+
+	requestTimeout := parseDurationAttribute(data.RequestTimeout, path.Root("request_timeout"), 0, &resp.Diagnostics)
 
-	if apiKey == "" {
+	maxConcurrentRequests := int(data.MaxConcurrentRequests.ValueInt64())
+	if maxConcurrentRequests < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_concurrent_requests"), "Invalid max_concurrent_requests",
+			"max_concurrent_requests must not be negative.")
+	}
+
+	requestsPerSecond := data.RequestsPerSecond.ValueFloat64()
+	if requestsPerSecond < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("requests_per_second"), "Invalid requests_per_second",
+			"requests_per_second must not be negative.")
+	}
+
+	if retryMinBackoff > retryMaxBackoff {
+		resp.Diagnostics.AddAttributeError(path.Root("retry_min_backoff"), "Invalid retry_min_backoff",
+			"retry_min_backoff must not be larger than retry_max_backoff.")
+	}
+
+	// The default key pair may be omitted when named keys are configured, but
+	// must be complete once either half of it is set.
+	requireDefault := len(keys) == 0 || apiKey != "" || apiSecret != ""
+
+	if requireDefault && apiKey == "" {
 		resp.Diagnostics.AddError("Livekit api key missing",
 			"The provider cannot create the Livekit client as there is a missing or empty value for the Livekit API key. "+
 				"Set the api_key value in the configuration or use the LIVEKIT_API_KEY environment variable. "+
 				"If either is already set, ensure the value is not empty.")
 	}
 
-	if apiSecret == "" {
+	if requireDefault && apiSecret == "" {
 		resp.Diagnostics.AddError("Livekit api secret missing",
 			"The provider cannot create the Livekit client as there is a missing or empty value for the Livekit API secret. "+
 				"Set the api_secret value in the configuration or use the LIVEKIT_API_SECRET environment variable. "+
@@ -83,20 +413,207 @@ func (p *LivekitProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	accessToken := auth.NewAccessToken(apiKey, apiSecret)
+	// Livekit Cloud issues keys of a fixed format, self-hosted servers accept
+	// any key, so the format is only enforced as a warning for Cloud urls.
+	isCloud := strings.HasSuffix(hostname(url), ".livekit.cloud")
+	strict := !data.DevMode.ValueBool()
+
+	keyPath, secretPath := path.Root("api_key"), path.Root("api_secret")
+	if !data.ApiKeyFile.IsNull() {
+		keyPath = path.Root("api_key_file")
+	}
+	if !data.ApiSecretFile.IsNull() {
+		secretPath = path.Root("api_secret_file")
+	}
+	if apiKey != "" {
+		validateKeyPair(apiKey, apiSecret, keyPath, secretPath, isCloud, strict, &resp.Diagnostics)
+	}
+	for key, secret := range keys {
+		validateKeyPair(key, secret, path.Root("keys"), path.Root("keys").AtMapKey(key), isCloud, strict, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := NewLivekitClient(LivekitClientConfig{
+		Url:                   url,
+		ApiKey:                apiKey,
+		ApiSecret:             apiSecret,
+		Keys:                  keys,
+		PreviousApiSecret:     data.PreviousApiSecret.ValueString(),
+		DefaultTokenTtl:       defaultTokenTtl,
+		RoomNamePrefix:        data.RoomNamePrefix.ValueString(),
+		MaxRetries:            maxRetries,
+		RetryMinBackoff:       retryMinBackoff,
+		RetryMaxBackoff:       retryMaxBackoff,
+		RequestTimeout:        requestTimeout,
+		MaxConcurrentRequests: maxConcurrentRequests,
+		RequestsPerSecond:     requestsPerSecond,
+		CaCertPem:             data.CaCertPem.ValueString(),
+		ClientCertPem:         data.ClientCertPem.ValueString(),
+		ClientKeyPem:          data.ClientKeyPem.ValueString(),
+		InsecureSkipVerify:    data.InsecureSkipVerify.ValueBool() || data.DevMode.ValueBool(),
+		UserAgent:             p.userAgent(req.TerraformVersion, data.UserAgentExtra.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create Livekit client", err.Error())
+		return
+	}
+
+	if data.ValidateCredentials.ValueBool() {
+		if err := client.ValidateCredentials(ctx); err != nil {
+			resp.Diagnostics.AddError("Livekit credentials invalid",
+				"The provider could not authenticate against the Livekit server: "+err.Error())
+			return
+		}
+	}
+
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
+
+// userAgent returns the User-Agent header sent with server API calls.
+func (p *LivekitProvider) userAgent(terraformVersion string, extra string) string {
+	userAgent := fmt.Sprintf("Terraform/%s terraform-provider-livekit/%s", terraformVersion, p.version)
+	if extra != "" {
+		userAgent += " " + extra
+	}
+	return userAgent
+}
+
+// valueOrDefault returns value unless it is empty.
+func valueOrDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// validateServerUrl checks that url points to a Livekit server using one of the
+// schemes understood by the Livekit SDKs.
+func validateServerUrl(serverUrl string) error {
+	u, err := neturl.Parse(serverUrl)
+	if err != nil {
+		return err
+	}
+
+	switch u.Scheme {
+	case "ws", "wss", "http", "https":
+	default:
+		return fmt.Errorf("url %q must use one of the schemes ws, wss, http or https", serverUrl)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("url %q has no host", serverUrl)
+	}
 
-	resp.DataSourceData = accessToken
-	resp.ResourceData = accessToken
+	return nil
+}
+
+// minApiSecretLength is the secret length livekit-server requires outside of dev mode.
+const minApiSecretLength = 32
+
+// validateKeyPair checks an API key and secret for mistakes that would
+// otherwise only surface as rejected tokens.
+func validateKeyPair(apiKey string, apiSecret string, keyPath path.Path, secretPath path.Path, isCloud bool, strict bool, diags *diag.Diagnostics) {
+	if strings.IndexFunc(apiKey, isSpaceOrControl) >= 0 {
+		diags.AddAttributeError(keyPath, "Invalid Livekit api key",
+			"The API key contains whitespace or control characters, which usually comes from copying it with surrounding text.")
+	}
+	if strings.IndexFunc(apiSecret, isSpaceOrControl) >= 0 {
+		diags.AddAttributeError(secretPath, "Invalid Livekit api secret",
+			"The API secret contains whitespace or control characters, which usually comes from copying it with surrounding text.")
+	}
+
+	if isCloud && (!strings.HasPrefix(apiKey, "API") || len(apiKey) < 12) {
+		diags.AddAttributeWarning(keyPath, "Unexpected Livekit api key format",
+			fmt.Sprintf("Livekit Cloud API keys start with API followed by at least 9 characters, got %q. "+
+				"Check that the key and secret are not swapped.", apiKey))
+	}
+
+	if strict && apiSecret != "" && len(apiSecret) < minApiSecretLength {
+		diags.AddAttributeWarning(secretPath, "Livekit api secret too short",
+			fmt.Sprintf("The API secret for key %q is shorter than %d characters, which livekit-server rejects outside of dev mode.",
+				apiKey, minApiSecretLength))
+	}
+}
+
+func isSpaceOrControl(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsControl(r)
+}
+
+// hostname returns the host of serverUrl without port, or an empty string
+// when it cannot be parsed.
+func hostname(serverUrl string) string {
+	u, err := neturl.Parse(serverUrl)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// readCredentialFile returns the content of a file holding a credential,
+// trimmed of the surrounding whitespace secret mounts usually add.
+func readCredentialFile(name string, attributePath path.Path, diags *diag.Diagnostics) string {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		diags.AddAttributeError(attributePath, "Unable to read credential file", err.Error())
+		return ""
+	}
+
+	return strings.TrimSpace(string(content))
+}
+
+// parseDurationAttribute parses an optional duration attribute, returning
+// defaultValue when it is not set.
+func parseDurationAttribute(value types.String, attributePath path.Path, defaultValue time.Duration, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(attributePath, "Invalid duration", err.Error())
+		return defaultValue
+	}
+	if duration < 0 {
+		diags.AddAttributeError(attributePath, "Invalid duration", "The duration must not be negative.")
+		return defaultValue
+	}
+
+	return duration
+}
+
+// clientErrorPath returns the attribute an error of ForEndpoint or
+// RequireServer is reported at.
+func clientErrorPath(err error) path.Path {
+	var keyErr *keyError
+	if errors.As(err, &keyErr) {
+		return path.Root("key_id")
+	}
+	return path.Root("endpoint")
 }
 
 func (p *LivekitProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAccessTokenResource,
+		NewAccessTokensResource,
+		NewRoomResource,
+		NewRoomMetadataResource,
+		NewParticipantPermissionsResource,
+		NewDataMessageResource,
+		NewTrackMuteResource,
+		NewParticipantSubscriptionsResource,
+		NewIngressResource,
 	}
 }
 
 func (p *LivekitProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewRoomsDataSource,
+		NewParticipantsDataSource,
+		NewRoomPresetDataSource,
+	}
 }
 
 func (p *LivekitProvider) Functions(ctx context.Context) []func() function.Function {
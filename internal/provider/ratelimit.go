@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+// limitInterceptor bounds the number of API calls in flight to maxConcurrent
+// and the number of calls started per second to requestsPerSecond. A zero
+// value disables the respective limit.
+func limitInterceptor(maxConcurrent int, requestsPerSecond float64) twirp.Interceptor {
+	var slots chan struct{}
+	if maxConcurrent > 0 {
+		slots = make(chan struct{}, maxConcurrent)
+	}
+
+	var limiter *rateLimiter
+	if requestsPerSecond > 0 {
+		limiter = &rateLimiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+	}
+
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if slots != nil {
+				select {
+				case slots <- struct{}{}:
+					defer func() { <-slots }()
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+
+			if limiter != nil {
+				if err := limiter.wait(ctx); err != nil {
+					return nil, err
+				}
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// rateLimiter spaces calls evenly, starting at most one call per interval.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/twitchtv/twirp"
+)
+
+const (
+	defaultMaxRetries      = 3
+	defaultRetryMinBackoff = 1 * time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// retryInterceptor retries API calls failing with a transient error, doubling
+// the wait between attempts from minBackoff up to maxBackoff.
+func retryInterceptor(maxRetries int, minBackoff time.Duration, maxBackoff time.Duration) twirp.Interceptor {
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			backoff := minBackoff
+
+			for attempt := 1; ; attempt++ {
+				resp, err := next(ctx, req)
+				method, _ := twirp.MethodName(ctx)
+				if err == nil || attempt > maxRetries || !isRetryable(method, err) {
+					return resp, err
+				}
+
+				tflog.Debug(ctx, "retrying Livekit API call", map[string]interface{}{
+					"method":  method,
+					"attempt": attempt,
+					"backoff": backoff.String(),
+					"error":   err.Error(),
+				})
+
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(backoff):
+				}
+
+				backoff = min(backoff*2, maxBackoff)
+			}
+		}
+	}
+}
+
+// isRetryable reports whether err is a transient failure worth another
+// attempt of method. Failures to connect to the server are always retried, as
+// the request was never sent. Other network errors, timeouts in particular, may
+// hit a request the server already processed, so they are only retried for
+// idempotent methods.
+func isRetryable(method string, err error) bool {
+	var twerr twirp.Error
+	if errors.As(err, &twerr) {
+		switch twerr.Code() {
+		case twirp.Unavailable, twirp.ResourceExhausted:
+			return true
+		}
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && isIdempotent(method)
+}
+
+// isIdempotent reports whether repeating a call of method has the same effect
+// as a single call. Creating resources and sending data do not.
+func isIdempotent(method string) bool {
+	return !strings.HasPrefix(method, "Create") && method != "SendData"
+}
@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &RoomMetadataResource{}
+var _ resource.ResourceWithImportState = &RoomMetadataResource{}
+
+func NewRoomMetadataResource() resource.Resource {
+	return &RoomMetadataResource{}
+}
+
+// RoomMetadataResource defines the resource implementation.
+type RoomMetadataResource struct {
+	client *LivekitClient
+}
+
+// RoomMetadataResourceModel describes the resource data model.
+type RoomMetadataResourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	Room     types.String `tfsdk:"room"`
+	Endpoint types.String `tfsdk:"endpoint"`
+	KeyId    types.String `tfsdk:"key_id"`
+	Metadata types.String `tfsdk:"metadata"`
+}
+
+func (r *RoomMetadataResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_metadata"
+}
+
+func (r *RoomMetadataResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Metadata of a Livekit room that is managed elsewhere",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, the room name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"room": schema.StringAttribute{
+				MarkdownDescription: "Name of the room, prefixed with the provider room_name_prefix on the server",
+				Required:            true,
+				Validators: []validator.String{
+					roomName(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Livekit server url of the room instead of the provider url",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "API key to sign the server API calls with, one of the keys configured on the provider. Defaults to the provider api_key",
+				Optional:            true,
+			},
+			"metadata": schema.StringAttribute{
+				MarkdownDescription: "Metadata of the room",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *RoomMetadataResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*LivekitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *LivekitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// serverClient returns the client for the endpoint of the room.
+func (r *RoomMetadataResource) serverClient(data *RoomMetadataResourceModel) (*LivekitClient, error) {
+	client, err := r.client.ForEndpoint(data.Endpoint.ValueString(), data.KeyId.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.RequireServer(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (r *RoomMetadataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomMetadataResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot update room metadata", err.Error())
+		return
+	}
+
+	room, err := updateRoomMetadata(ctx, client, client.RoomName(data.Room.ValueString()), data.Metadata.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating room metadata", err.Error())
+		return
+	}
+
+	data.Id = data.Room
+	data.Metadata = types.StringValue(room.Metadata)
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomMetadataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomMetadataResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot read room metadata", err.Error())
+		return
+	}
+
+	room, err := findRoom(ctx, client, client.RoomName(data.Room.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading room metadata", err.Error())
+		return
+	}
+
+	// The metadata is set again once the room exists again.
+	if room == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Metadata = types.StringValue(room.Metadata)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomMetadataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoomMetadataResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot update room metadata", err.Error())
+		return
+	}
+
+	room, err := updateRoomMetadata(ctx, client, client.RoomName(data.Room.ValueString()), data.Metadata.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating room metadata", err.Error())
+		return
+	}
+
+	data.Metadata = types.StringValue(room.Metadata)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomMetadataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The room is not managed by this resource, its metadata is left as is.
+}
+
+func (r *RoomMetadataResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "The import ID must be the name of the room.")
+		return
+	}
+
+	// Accept the name on the server as well, including the room_name_prefix.
+	name := r.client.ConfiguredRoomName(req.ID)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("room"), name)...)
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &RoomPresetDataSource{}
+
+func NewRoomPresetDataSource() datasource.DataSource {
+	return &RoomPresetDataSource{}
+}
+
+// RoomPresetDataSource defines the data source implementation. It only
+// assembles its configuration and does not call the server.
+type RoomPresetDataSource struct{}
+
+// RoomPresetDataSourceModel describes the data source data model.
+type RoomPresetDataSourceModel struct {
+	EmptyTimeout     types.Int64 `tfsdk:"empty_timeout"`
+	DepartureTimeout types.Int64 `tfsdk:"departure_timeout"`
+	MaxParticipants  types.Int64 `tfsdk:"max_participants"`
+	MinPlayoutDelay  types.Int64 `tfsdk:"min_playout_delay"`
+	MaxPlayoutDelay  types.Int64 `tfsdk:"max_playout_delay"`
+	SyncStreams      types.Bool  `tfsdk:"sync_streams"`
+	Agents           types.List  `tfsdk:"agents"`
+
+	RoomConfig types.Object `tfsdk:"room_config"`
+	Json       types.String `tfsdk:"json"`
+}
+
+func (d *RoomPresetDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room_preset"
+}
+
+func (d *RoomPresetDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	agentAttributes := map[string]schema.Attribute{
+		"agent_name": schema.StringAttribute{
+			MarkdownDescription: "Name the agent worker registered with",
+			Required:            true,
+		},
+		"metadata": schema.StringAttribute{
+			MarkdownDescription: "Metadata handed to the agent job, e.g. a JSON document with instructions",
+			Optional:            true,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reusable Livekit room configuration",
+
+		Attributes: map[string]schema.Attribute{
+			"empty_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Seconds to keep the room open when nobody joins",
+				Optional:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+			},
+			"departure_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Seconds to keep the room open after the last participant left",
+				Optional:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+			},
+			"max_participants": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of participants in the room",
+				Optional:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+			},
+			"min_playout_delay": schema.Int64Attribute{
+				MarkdownDescription: "Minimum playout delay of subscribed tracks in milliseconds",
+				Optional:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+			},
+			"max_playout_delay": schema.Int64Attribute{
+				MarkdownDescription: "Maximum playout delay of subscribed tracks in milliseconds",
+				Optional:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+			},
+			"sync_streams": schema.BoolAttribute{
+				MarkdownDescription: "Synchronize the audio and video tracks of each participant",
+				Optional:            true,
+			},
+			"agents": schema.ListNestedAttribute{
+				MarkdownDescription: "Agents dispatched into the room",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: agentAttributes,
+				},
+			},
+			"room_config": schema.SingleNestedAttribute{
+				MarkdownDescription: "The configuration as an object with the attributes of the livekit_access_token room_config block",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"empty_timeout":     schema.Int64Attribute{Computed: true, MarkdownDescription: "Seconds to keep the room open when nobody joins"},
+					"departure_timeout": schema.Int64Attribute{Computed: true, MarkdownDescription: "Seconds to keep the room open after the last participant left"},
+					"max_participants":  schema.Int64Attribute{Computed: true, MarkdownDescription: "Maximum number of participants in the room"},
+					"min_playout_delay": schema.Int64Attribute{Computed: true, MarkdownDescription: "Minimum playout delay of subscribed tracks in milliseconds"},
+					"max_playout_delay": schema.Int64Attribute{Computed: true, MarkdownDescription: "Maximum playout delay of subscribed tracks in milliseconds"},
+					"sync_streams":      schema.BoolAttribute{Computed: true, MarkdownDescription: "Synchronize the audio and video tracks of each participant"},
+					"agents": schema.ListNestedAttribute{
+						MarkdownDescription: "Agents dispatched into the room",
+						Computed:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"agent_name": schema.StringAttribute{Computed: true, MarkdownDescription: "Name the agent worker registered with"},
+								"metadata":   schema.StringAttribute{Computed: true, MarkdownDescription: "Metadata handed to the agent job"},
+							},
+						},
+					},
+				},
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The configuration as a RoomConfiguration JSON document, e.g. for SIP dispatch rules or the Livekit CLI",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RoomPresetDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoomPresetDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roomConfig, diags := types.ObjectValueFrom(ctx, accessTokenRoomConfigAttributeTypes, AccessTokenRoomConfigModel{
+		EmptyTimeout:     data.EmptyTimeout,
+		DepartureTimeout: data.DepartureTimeout,
+		MaxParticipants:  data.MaxParticipants,
+		MinPlayoutDelay:  data.MinPlayoutDelay,
+		MaxPlayoutDelay:  data.MaxPlayoutDelay,
+		SyncStreams:      types.BoolValue(data.SyncStreams.ValueBool()),
+		Agents:           data.Agents,
+	})
+	resp.Diagnostics.Append(diags...)
+	data.RoomConfig = roomConfig
+
+	var agents []AccessTokenRoomAgentModel
+	if !data.Agents.IsNull() {
+		resp.Diagnostics.Append(data.Agents.ElementsAs(ctx, &agents, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := &roomConfiguration{
+		EmptyTimeout:     uint32(data.EmptyTimeout.ValueInt64()),
+		DepartureTimeout: uint32(data.DepartureTimeout.ValueInt64()),
+		MaxParticipants:  uint32(data.MaxParticipants.ValueInt64()),
+		MinPlayoutDelay:  uint32(data.MinPlayoutDelay.ValueInt64()),
+		MaxPlayoutDelay:  uint32(data.MaxPlayoutDelay.ValueInt64()),
+		SyncStreams:      data.SyncStreams.ValueBool(),
+	}
+	for _, agent := range agents {
+		config.Agents = append(config.Agents, roomAgentDispatch{
+			AgentName: agent.AgentName.ValueString(),
+			Metadata:  agent.Metadata.ValueString(),
+		})
+	}
+
+	content, err := json.Marshal(config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error encoding room configuration", err.Error())
+		return
+	}
+	data.Json = types.StringValue(string(content))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,703 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+)
+
+var _ resource.Resource = &RoomResource{}
+var _ resource.ResourceWithModifyPlan = &RoomResource{}
+var _ resource.ResourceWithImportState = &RoomResource{}
+
+const (
+	defaultWaitForEmptyTimeout = 10 * time.Minute
+	waitForEmptyInterval       = 5 * time.Second
+
+	// forceDeleteReserve is the part of the delete timeout, at most a quarter
+	// of it, that waiting for an empty room leaves to force_delete.
+	forceDeleteReserve = time.Minute
+)
+
+func NewRoomResource() resource.Resource {
+	return &RoomResource{}
+}
+
+// RoomResource defines the resource implementation.
+type RoomResource struct {
+	client *LivekitClient
+}
+
+// RoomResourceModel describes the resource data model.
+type RoomResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Endpoint    types.String `tfsdk:"endpoint"`
+	KeyId       types.String `tfsdk:"key_id"`
+	NodeId      types.String `tfsdk:"node_id"`
+	Persistent  types.Bool   `tfsdk:"persistent"`
+	ForceDelete types.Bool   `tfsdk:"force_delete"`
+
+	WaitForEmpty        types.Bool   `tfsdk:"wait_for_empty"`
+	WaitForEmptyTimeout types.String `tfsdk:"wait_for_empty_timeout"`
+
+	EmptyTimeout     types.Int64 `tfsdk:"empty_timeout"`
+	DepartureTimeout types.Int64 `tfsdk:"departure_timeout"`
+	MaxParticipants  types.Int64 `tfsdk:"max_participants"`
+
+	Metadata       types.String `tfsdk:"metadata"`
+	ManageMetadata types.Bool   `tfsdk:"manage_metadata"`
+
+	MinPlayoutDelay types.Int64 `tfsdk:"min_playout_delay"`
+	MaxPlayoutDelay types.Int64 `tfsdk:"max_playout_delay"`
+	SyncStreams     types.Bool  `tfsdk:"sync_streams"`
+
+	Egress   types.Object `tfsdk:"egress"`
+	Timeouts types.Object `tfsdk:"timeouts"`
+
+	Sid          types.String `tfsdk:"sid"`
+	CreationTime types.String `tfsdk:"creation_time"`
+	TurnPassword types.String `tfsdk:"turn_password"`
+}
+
+// RoomTimeoutsModel describes the timeouts block of the room resource.
+type RoomTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+func (r *RoomResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_room"
+}
+
+func (r *RoomResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Livekit room",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, the room name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Room name, prefixed with the provider room_name_prefix on the server",
+				Required:            true,
+				Validators: []validator.String{
+					roomName(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Livekit server url to manage the room on instead of the provider url",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "API key to sign the server API calls with, one of the keys configured on the provider. Defaults to the provider api_key",
+				Optional:            true,
+			},
+			"node_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the media node to create the room on",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"persistent": schema.BoolAttribute{
+				MarkdownDescription: "Create the room again when it was deleted outside of Terraform, e.g. by the server once it emptied out. Defaults to true",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"force_delete": schema.BoolAttribute{
+				MarkdownDescription: "Remove every participant from the room before deleting it. Defaults to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_for_empty": schema.BoolAttribute{
+				MarkdownDescription: "Wait for every participant to leave the room before deleting it. Defaults to false",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_for_empty_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the room to empty out, e.g. 30m. Participants are removed afterwards with force_delete, deleting the room fails otherwise. Defaults to 10m",
+				Optional:            true,
+			},
+			"empty_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Seconds to keep the room open when nobody joins. Defaults to the server setting",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"departure_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Seconds to keep the room open after the last participant left. Defaults to the server setting",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"max_participants": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of participants in the room. Defaults to the server setting, usually unlimited",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"metadata": schema.StringAttribute{
+				MarkdownDescription: "Metadata of the room, updated in place without disconnecting participants",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"manage_metadata": schema.BoolAttribute{
+				MarkdownDescription: "Keep the metadata of the room in sync with metadata. Set it to false to only set the metadata when creating the room and leave later changes to the application. Defaults to true",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"min_playout_delay": schema.Int64Attribute{
+				MarkdownDescription: "Minimum playout delay of subscribers in milliseconds",
+				Optional:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"max_playout_delay": schema.Int64Attribute{
+				MarkdownDescription: "Maximum playout delay of subscribers in milliseconds",
+				Optional:            true,
+				Validators: []validator.Int64{
+					positiveInt64(),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"sync_streams": schema.BoolAttribute{
+				MarkdownDescription: "Synchronize the audio and video tracks of a participant for subscribers",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"sid": schema.StringAttribute{
+				MarkdownDescription: "Server assigned id of the room, as in webhooks and analytics",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creation_time": schema.StringAttribute{
+				MarkdownDescription: "Creation time of the room as an RFC3339 timestamp",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"turn_password": schema.StringAttribute{
+				MarkdownDescription: "Password of the TURN server for the room",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"egress": schema.SingleNestedBlock{
+				MarkdownDescription: "Egress started automatically when the room starts",
+				Blocks:              roomEgressBlocks(),
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeouts": schema.SingleNestedBlock{
+				MarkdownDescription: "Timeouts of creating and deleting the room, including retries",
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{
+						MarkdownDescription: "Timeout of creating the room, e.g. 5m. Not bounded by default",
+						Optional:            true,
+					},
+					"delete": schema.StringAttribute{
+						MarkdownDescription: "Timeout of deleting the room, e.g. 10m. Not bounded by default",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *RoomResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*LivekitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *LivekitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RoomResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data RoomResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Report invalid egress outputs and timeouts before the room is created.
+	roomEgress(ctx, data.Egress, &resp.Diagnostics)
+	roomTimeouts(ctx, data.Timeouts, &resp.Diagnostics)
+	parseDurationAttribute(data.WaitForEmptyTimeout, path.Root("wait_for_empty_timeout"), defaultWaitForEmptyTimeout, &resp.Diagnostics)
+}
+
+// roomTimeouts returns the create and delete timeouts of the timeouts block
+// value. Timeouts that are not set are zero.
+func roomTimeouts(ctx context.Context, value types.Object, diags *diag.Diagnostics) (time.Duration, time.Duration) {
+	if value.IsNull() || value.IsUnknown() {
+		return 0, 0
+	}
+
+	var timeouts RoomTimeoutsModel
+	diags.Append(value.As(ctx, &timeouts, basetypes.ObjectAsOptions{})...)
+
+	createTimeout := parseDurationAttribute(timeouts.Create, path.Root("timeouts").AtName("create"), 0, diags)
+	deleteTimeout := parseDurationAttribute(timeouts.Delete, path.Root("timeouts").AtName("delete"), 0, diags)
+	return createTimeout, deleteTimeout
+}
+
+// withTimeout bounds ctx by timeout. A zero timeout leaves ctx unbounded.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// serverClient returns the client for the endpoint of the room.
+func (r *RoomResource) serverClient(data *RoomResourceModel) (*LivekitClient, error) {
+	client, err := r.client.ForEndpoint(data.Endpoint.ValueString(), data.KeyId.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.RequireServer(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (r *RoomResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoomResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	createTimeout, _ := roomTimeouts(ctx, data.Timeouts, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot create room", err.Error())
+		return
+	}
+
+	roomName := client.RoomName(data.Name.ValueString())
+
+	egress := roomEgress(ctx, data.Egress, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomCreate: true, RoomRecord: egress != nil})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	room, err := client.RoomService.CreateRoom(authCtx, &livekit.CreateRoomRequest{
+		Name:             roomName,
+		EmptyTimeout:     uint32(data.EmptyTimeout.ValueInt64()),
+		DepartureTimeout: uint32(data.DepartureTimeout.ValueInt64()),
+		MaxParticipants:  uint32(data.MaxParticipants.ValueInt64()),
+		Metadata:         data.Metadata.ValueString(),
+		NodeId:           data.NodeId.ValueString(),
+		MinPlayoutDelay:  uint32(data.MinPlayoutDelay.ValueInt64()),
+		MaxPlayoutDelay:  uint32(data.MaxPlayoutDelay.ValueInt64()),
+		SyncStreams:      data.SyncStreams.ValueBool(),
+		Egress:           egress,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating room", fmt.Sprintf("Could not create room %q: %s", roomName, err))
+		return
+	}
+
+	data.Id = data.Name
+	setRoomAttributes(&data, room)
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoomResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot read room", err.Error())
+		return
+	}
+
+	room, err := findRoom(ctx, client, client.RoomName(data.Name.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading room", err.Error())
+		return
+	}
+
+	if room == nil {
+		// Rooms that are not persistent are expected to close, keep them
+		// as they were last seen.
+		if !data.Persistent.ValueBool() {
+			tflog.Info(ctx, "room is gone, keeping it as it is not persistent", map[string]interface{}{"room": data.Name.ValueString()})
+			return
+		}
+
+		// Rooms deleted outside of Terraform are created again.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Metadata changed by the application is not drift when it is not
+	// managed.
+	metadata := data.Metadata
+	setRoomAttributes(&data, room)
+	if !data.ManageMetadata.ValueBool() {
+		data.Metadata = metadata
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setRoomAttributes copies the settings of room as reported by the server
+// into data.
+func setRoomAttributes(data *RoomResourceModel, room *livekit.Room) {
+	data.EmptyTimeout = types.Int64Value(int64(room.EmptyTimeout))
+	data.DepartureTimeout = types.Int64Value(int64(room.DepartureTimeout))
+	data.MaxParticipants = types.Int64Value(int64(room.MaxParticipants))
+	data.Metadata = types.StringValue(room.Metadata)
+	data.Sid = types.StringValue(room.Sid)
+	data.CreationTime = types.StringValue(formatTime(time.Unix(room.CreationTime, 0)))
+	data.TurnPassword = types.StringValue(room.TurnPassword)
+}
+
+// findRoom returns the room named roomName on the server of client, or nil
+// when there is no such room.
+func findRoom(ctx context.Context, client *LivekitClient, roomName string) (*livekit.Room, error) {
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomList: true})
+	if err != nil {
+		return nil, fmt.Errorf("error creating API token: %w", err)
+	}
+
+	rooms, err := client.RoomService.ListRooms(authCtx, &livekit.ListRoomsRequest{
+		Names: []string{roomName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list room %q: %w", roomName, err)
+	}
+
+	for _, room := range rooms.Rooms {
+		if room.Name == roomName {
+			return room, nil
+		}
+	}
+	return nil, nil
+}
+
+// updateRoomMetadata sets the metadata of the room named roomName on the
+// server of client and returns the updated room.
+func updateRoomMetadata(ctx context.Context, client *LivekitClient, roomName string, metadata string) (*livekit.Room, error) {
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomAdmin: true, Room: roomName})
+	if err != nil {
+		return nil, fmt.Errorf("error creating API token: %w", err)
+	}
+
+	room, err := client.RoomService.UpdateRoomMetadata(authCtx, &livekit.UpdateRoomMetadataRequest{
+		Room:     roomName,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not update metadata of room %q: %w", roomName, err)
+	}
+	return room, nil
+}
+
+func (r *RoomResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RoomResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The metadata is the only setting that can change without replacing
+	// the room. It is left to the application when it is not managed.
+	if data.ManageMetadata.ValueBool() && !data.Metadata.Equal(state.Metadata) {
+		client, err := r.serverClient(&data)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot update room", err.Error())
+			return
+		}
+
+		roomName := client.RoomName(data.Name.ValueString())
+
+		room, err := updateRoomMetadata(ctx, client, roomName, data.Metadata.ValueString())
+		switch {
+		case err == nil:
+			setRoomAttributes(&data, room)
+		case isNotFound(err) && !data.Persistent.ValueBool():
+			tflog.Info(ctx, "room is gone, not updating its metadata as it is not persistent", map[string]interface{}{"room": roomName})
+		default:
+			resp.Diagnostics.AddError("Error updating room", err.Error())
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoomResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoomResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	_, deleteTimeout := roomTimeouts(ctx, data.Timeouts, &resp.Diagnostics)
+	waitForEmptyTimeout := parseDurationAttribute(data.WaitForEmptyTimeout, path.Root("wait_for_empty_timeout"), defaultWaitForEmptyTimeout, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot delete room", err.Error())
+		return
+	}
+
+	roomName := client.RoomName(data.Name.ValueString())
+
+	if data.WaitForEmpty.ValueBool() {
+		// Stop waiting early enough to remove the remaining participants and
+		// delete the room within the delete timeout.
+		waitCtx := ctx
+		if deadline, ok := ctx.Deadline(); ok && data.ForceDelete.ValueBool() {
+			var cancel context.CancelFunc
+			waitCtx, cancel = context.WithDeadline(ctx, deadline.Add(-min(forceDeleteReserve, time.Until(deadline)/4)))
+			defer cancel()
+		}
+
+		err := waitForEmptyRoom(waitCtx, client, roomName, waitForEmptyTimeout)
+		if errors.Is(err, context.DeadlineExceeded) && data.ForceDelete.ValueBool() {
+			tflog.Info(ctx, "room did not empty out, removing the remaining participants", map[string]interface{}{"room": roomName})
+		} else if err != nil {
+			resp.Diagnostics.AddError("Error waiting for room to empty out", err.Error())
+			return
+		}
+	}
+
+	if data.ForceDelete.ValueBool() {
+		if err := removeParticipants(ctx, client, roomName); err != nil {
+			resp.Diagnostics.AddError("Error removing participants", err.Error())
+			return
+		}
+	}
+
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomCreate: true})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	_, err = client.RoomService.DeleteRoom(authCtx, &livekit.DeleteRoomRequest{
+		Room: roomName,
+	})
+	// A room that is already gone is as good as deleted.
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting room", fmt.Sprintf("Could not delete room %q: %s", roomName, err))
+		return
+	}
+}
+
+// waitForEmptyRoom polls the room named roomName on the server of client
+// until it has no participants left or is gone, for at most timeout.
+func waitForEmptyRoom(ctx context.Context, client *LivekitClient, roomName string, timeout time.Duration) error {
+	waitCtx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		room, err := findRoom(waitCtx, client, roomName)
+		if err != nil && waitCtx.Err() != nil {
+			return fmt.Errorf("room %q did not empty out within %s: %w", roomName, timeout, waitCtx.Err())
+		}
+		if err != nil {
+			return err
+		}
+		if room == nil || room.NumParticipants == 0 {
+			return nil
+		}
+
+		tflog.Debug(ctx, "waiting for room to empty out", map[string]interface{}{
+			"room":         roomName,
+			"participants": room.NumParticipants,
+		})
+
+		select {
+		case <-waitCtx.Done():
+			return fmt.Errorf("room %q still has %d participants after %s: %w", roomName, room.NumParticipants, timeout, waitCtx.Err())
+		case <-time.After(waitForEmptyInterval):
+		}
+	}
+}
+
+// removeParticipants removes every participant from the room named roomName
+// on the server of client.
+func removeParticipants(ctx context.Context, client *LivekitClient, roomName string) error {
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomAdmin: true, Room: roomName})
+	if err != nil {
+		return fmt.Errorf("error creating API token: %w", err)
+	}
+
+	participants, err := client.RoomService.ListParticipants(authCtx, &livekit.ListParticipantsRequest{
+		Room: roomName,
+	})
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not list participants of room %q: %w", roomName, err)
+	}
+
+	for _, participant := range participants.Participants {
+		_, err := client.RoomService.RemoveParticipant(authCtx, &livekit.RoomParticipantIdentity{
+			Room:     roomName,
+			Identity: participant.Identity,
+		})
+		// Participants may leave on their own in the meantime.
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("could not remove participant %q from room %q: %w", participant.Identity, roomName, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *RoomResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "The import ID must be the name of the room.")
+		return
+	}
+
+	// Accept the name on the server as well, including the room_name_prefix.
+	name := r.client.ConfiguredRoomName(req.ID)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+
+	// Settings that only exist in the configuration take their defaults, so
+	// that the next plan shows no change for them.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("persistent"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("manage_metadata"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_delete"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_empty"), false)...)
+}
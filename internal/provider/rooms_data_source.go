@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+)
+
+var _ datasource.DataSource = &RoomsDataSource{}
+
+func NewRoomsDataSource() datasource.DataSource {
+	return &RoomsDataSource{}
+}
+
+// RoomsDataSource defines the data source implementation.
+type RoomsDataSource struct {
+	client *LivekitClient
+}
+
+// RoomsDataSourceModel describes the data source data model.
+type RoomsDataSourceModel struct {
+	Endpoint   types.String `tfsdk:"endpoint"`
+	KeyId      types.String `tfsdk:"key_id"`
+	Names      types.Set    `tfsdk:"names"`
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	Rooms      types.List   `tfsdk:"rooms"`
+}
+
+// RoomsDataSourceRoomModel describes a room of the data source data model.
+type RoomsDataSourceRoomModel struct {
+	Name             types.String `tfsdk:"name"`
+	Sid              types.String `tfsdk:"sid"`
+	Metadata         types.String `tfsdk:"metadata"`
+	NumParticipants  types.Int64  `tfsdk:"num_participants"`
+	NumPublishers    types.Int64  `tfsdk:"num_publishers"`
+	EmptyTimeout     types.Int64  `tfsdk:"empty_timeout"`
+	DepartureTimeout types.Int64  `tfsdk:"departure_timeout"`
+	MaxParticipants  types.Int64  `tfsdk:"max_participants"`
+	CreationTime     types.String `tfsdk:"creation_time"`
+	ActiveRecording  types.Bool   `tfsdk:"active_recording"`
+}
+
+var roomsDataSourceRoomAttributeTypes = map[string]attr.Type{
+	"name":              types.StringType,
+	"sid":               types.StringType,
+	"metadata":          types.StringType,
+	"num_participants":  types.Int64Type,
+	"num_publishers":    types.Int64Type,
+	"empty_timeout":     types.Int64Type,
+	"departure_timeout": types.Int64Type,
+	"max_participants":  types.Int64Type,
+	"creation_time":     types.StringType,
+	"active_recording":  types.BoolType,
+}
+
+func (d *RoomsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rooms"
+}
+
+func (d *RoomsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Open Livekit rooms",
+
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Livekit server url to list the rooms of instead of the provider url",
+				Optional:            true,
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "API key to sign the server API calls with, one of the keys configured on the provider. Defaults to the provider api_key",
+				Optional:            true,
+			},
+			"names": schema.SetAttribute{
+				MarkdownDescription: "Only list the rooms with these names",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					roomName(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list the rooms whose name starts with this prefix",
+				Optional:            true,
+			},
+			"rooms": schema.ListNestedAttribute{
+				MarkdownDescription: "The rooms, ordered by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Room name, without the provider room_name_prefix",
+							Computed:            true,
+						},
+						"sid": schema.StringAttribute{
+							MarkdownDescription: "Server assigned id of the room",
+							Computed:            true,
+						},
+						"metadata": schema.StringAttribute{
+							MarkdownDescription: "Metadata of the room",
+							Computed:            true,
+						},
+						"num_participants": schema.Int64Attribute{
+							MarkdownDescription: "Number of connected participants",
+							Computed:            true,
+						},
+						"num_publishers": schema.Int64Attribute{
+							MarkdownDescription: "Number of participants publishing tracks",
+							Computed:            true,
+						},
+						"empty_timeout": schema.Int64Attribute{
+							MarkdownDescription: "Seconds the room is kept open when nobody joins",
+							Computed:            true,
+						},
+						"departure_timeout": schema.Int64Attribute{
+							MarkdownDescription: "Seconds the room is kept open after the last participant left",
+							Computed:            true,
+						},
+						"max_participants": schema.Int64Attribute{
+							MarkdownDescription: "Maximum number of participants, 0 when unlimited",
+							Computed:            true,
+						},
+						"creation_time": schema.StringAttribute{
+							MarkdownDescription: "Creation time of the room as an RFC3339 timestamp",
+							Computed:            true,
+						},
+						"active_recording": schema.BoolAttribute{
+							MarkdownDescription: "Whether the room is being recorded",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RoomsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*LivekitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *LivekitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RoomsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoomsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client.ForEndpoint(data.Endpoint.ValueString(), data.KeyId.ValueString())
+	if err == nil {
+		err = client.RequireServer()
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot list rooms", err.Error())
+		return
+	}
+
+	var names []string
+	resp.Diagnostics.Append(data.Names.ElementsAs(ctx, &names, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := &livekit.ListRoomsRequest{}
+	for _, name := range names {
+		request.Names = append(request.Names, client.RoomName(name))
+	}
+
+	// An empty filter lists all rooms.
+	if !data.Names.IsNull() && len(names) == 0 {
+		data.Rooms = types.ListValueMust(types.ObjectType{AttrTypes: roomsDataSourceRoomAttributeTypes}, []attr.Value{})
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomList: true})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	// The API returns all rooms at once, it does not paginate.
+	response, err := client.RoomService.ListRooms(authCtx, request)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing rooms", err.Error())
+		return
+	}
+
+	rooms := []RoomsDataSourceRoomModel{}
+	for _, room := range response.Rooms {
+		// Rooms of other configurations sharing the server are skipped.
+		if !strings.HasPrefix(room.Name, client.RoomNamePrefix) {
+			continue
+		}
+
+		name := client.ConfiguredRoomName(room.Name)
+		if !strings.HasPrefix(name, data.NamePrefix.ValueString()) {
+			continue
+		}
+
+		rooms = append(rooms, RoomsDataSourceRoomModel{
+			Name:             types.StringValue(name),
+			Sid:              types.StringValue(room.Sid),
+			Metadata:         types.StringValue(room.Metadata),
+			NumParticipants:  types.Int64Value(int64(room.NumParticipants)),
+			NumPublishers:    types.Int64Value(int64(room.NumPublishers)),
+			EmptyTimeout:     types.Int64Value(int64(room.EmptyTimeout)),
+			DepartureTimeout: types.Int64Value(int64(room.DepartureTimeout)),
+			MaxParticipants:  types.Int64Value(int64(room.MaxParticipants)),
+			CreationTime:     types.StringValue(formatTime(time.Unix(room.CreationTime, 0))),
+			ActiveRecording:  types.BoolValue(room.ActiveRecording),
+		})
+	}
+
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].Name.ValueString() < rooms[j].Name.ValueString()
+	})
+
+	roomsValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: roomsDataSourceRoomAttributeTypes}, rooms)
+	resp.Diagnostics.Append(diags...)
+	data.Rooms = roomsValue
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/livekit/protocol/auth"
+)
+
+// reservedClaims are the claims set by signToken, which extra claims must
+// not override.
+var reservedClaims = []string{
+	"iss", "sub", "aud", "exp", "nbf", "iat", "jti",
+	"video", "sip", "name", "metadata", "attributes", "kind", "sha256", "roomPreset", "roomConfig",
+}
+
+// roomAgentDispatch is an agent dispatched into the room when a participant
+// joins with the token, an entry of the agents of the room configuration.
+type roomAgentDispatch struct {
+	AgentName string `json:"agentName"`
+	Metadata  string `json:"metadata,omitempty"`
+}
+
+// roomConfiguration is the configuration of the room created when a
+// participant joins with the token, encoded like livekit.RoomConfiguration.
+type roomConfiguration struct {
+	EmptyTimeout     uint32              `json:"emptyTimeout,omitempty"`
+	DepartureTimeout uint32              `json:"departureTimeout,omitempty"`
+	MaxParticipants  uint32              `json:"maxParticipants,omitempty"`
+	MinPlayoutDelay  uint32              `json:"minPlayoutDelay,omitempty"`
+	MaxPlayoutDelay  uint32              `json:"maxPlayoutDelay,omitempty"`
+	SyncStreams      bool                `json:"syncStreams,omitempty"`
+	Agents           []roomAgentDispatch `json:"agents,omitempty"`
+}
+
+// grantExtensions holds grants that auth.ClaimGrants does not model yet. They
+// are merged into the encoded grants.
+type grantExtensions struct {
+	// RoomConfig is added as the roomConfig claim.
+	RoomConfig *roomConfiguration
+
+	// RoomPreset is added as the roomPreset claim.
+	RoomPreset string
+
+	// CanSubscribeMetrics is added to the video grant.
+	CanSubscribeMetrics bool
+}
+
+// signToken signs grants the same way auth.AccessToken does, but with the
+// registered claims chosen by the caller. Issuer and subject are always set
+// from apiKey and the grants identity. ext may be nil. Extra claims are added
+// as they are, they never override the reserved ones.
+func signToken(apiKey, apiSecret string, grants *auth.ClaimGrants, ext *grantExtensions, registered jwt.RegisteredClaims, extra map[string]string) (string, error) {
+	claims, err := tokenPayload(apiKey, grants, ext, registered, extra)
+	if err != nil {
+		return "", err
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(apiSecret))
+}
+
+// previewToken decodes the payload signToken would sign, without signing it.
+func previewToken(apiKey string, grants *auth.ClaimGrants, ext *grantExtensions, registered jwt.RegisteredClaims, extra map[string]string) (*LivekitTokenClaims, error) {
+	claims, err := tokenPayload(apiKey, grants, ext, registered, extra)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(claims)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding claims: %w", err)
+	}
+
+	token := &LivekitTokenClaims{}
+	if err := json.Unmarshal(encoded, token); err != nil {
+		return nil, fmt.Errorf("error decoding claims: %w", err)
+	}
+
+	return token, nil
+}
+
+// tokenPayload merges grants, their extensions, registered and extra claims
+// into the payload of a token.
+func tokenPayload(apiKey string, grants *auth.ClaimGrants, ext *grantExtensions, registered jwt.RegisteredClaims, extra map[string]string) (jwt.MapClaims, error) {
+	registered.Issuer = apiKey
+	registered.Subject = grants.Identity
+
+	claims := jwt.MapClaims{}
+	for name, value := range extra {
+		claims[name] = value
+	}
+	for _, part := range []interface{}{grants, registered} {
+		encoded, err := json.Marshal(part)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding claims: %w", err)
+		}
+		if err := json.Unmarshal(encoded, &claims); err != nil {
+			return nil, fmt.Errorf("error encoding claims: %w", err)
+		}
+	}
+
+	if ext != nil {
+		mergeGrantExtensions(claims, ext)
+	}
+
+	return claims, nil
+}
+
+// mergeGrantExtensions adds ext to the encoded grants in claims.
+func mergeGrantExtensions(claims jwt.MapClaims, ext *grantExtensions) {
+	if ext.RoomConfig != nil {
+		claims["roomConfig"] = ext.RoomConfig
+	}
+
+	if ext.RoomPreset != "" {
+		claims["roomPreset"] = ext.RoomPreset
+	}
+
+	if ext.CanSubscribeMetrics {
+		video, _ := claims["video"].(map[string]interface{})
+		if video == nil {
+			video = map[string]interface{}{}
+		}
+		video["canSubscribeMetrics"] = true
+		claims["video"] = video
+	}
+}
+
+// tokenSignedWith verifies the signature of token against secret. Expiry is
+// not checked.
+func tokenSignedWith(token, secret string) bool {
+	if secret == "" {
+		return false
+	}
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithoutClaimsValidation(),
+	)
+	_, err := parser.Parse(token, func(*jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	return err == nil
+}
+
+// parseNotBefore parses a not_before value, either an RFC3339 timestamp or a
+// duration offset from now.
+func parseNotBefore(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	offset, err := parseTokenDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC3339 timestamp nor a duration", value)
+	}
+
+	return now.Add(offset), nil
+}
+
+// formatTime formats token timestamps for the state, at the second precision
+// of JWT claims.
+func formatTime(t time.Time) string {
+	return t.UTC().Truncate(time.Second).Format(time.RFC3339)
+}
+
+// sameTime reports whether two RFC3339 timestamps denote the same second,
+// regardless of their time zone.
+func sameTime(a, b string) bool {
+	ta, errA := time.Parse(time.RFC3339, a)
+	tb, errB := time.Parse(time.RFC3339, b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return ta.Truncate(time.Second).Equal(tb.Truncate(time.Second))
+}
+
+// joinUrl returns a link that opens a Livekit Meet instance at meetUrl and
+// connects it to the server at serverUrl with token.
+func joinUrl(meetUrl, serverUrl, token string) string {
+	query := neturl.Values{}
+	query.Set("liveKitUrl", toWebsocketUrl(serverUrl))
+	query.Set("token", token)
+	return strings.TrimSuffix(meetUrl, "/") + "/custom?" + query.Encode()
+}
+
+// tokenDigest returns the hex encoded SHA-256 digest of token.
+func tokenDigest(token string) string {
+	digest := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(digest[:])
+}
@@ -0,0 +1,336 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+)
+
+var _ resource.Resource = &TrackMuteResource{}
+var _ resource.ResourceWithModifyPlan = &TrackMuteResource{}
+
+func NewTrackMuteResource() resource.Resource {
+	return &TrackMuteResource{}
+}
+
+// TrackMuteResource defines the resource implementation.
+type TrackMuteResource struct {
+	client *LivekitClient
+}
+
+// TrackMuteResourceModel describes the resource data model.
+type TrackMuteResourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	Room     types.String `tfsdk:"room"`
+	Identity types.String `tfsdk:"identity"`
+	Endpoint types.String `tfsdk:"endpoint"`
+	KeyId    types.String `tfsdk:"key_id"`
+	TrackSid types.String `tfsdk:"track_sid"`
+	Source   types.String `tfsdk:"source"`
+	Muted    types.Bool   `tfsdk:"muted"`
+}
+
+func (r *TrackMuteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_track_mute"
+}
+
+func (r *TrackMuteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Mute state of a track published in a Livekit room",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource, the room name, identity and track source or sid separated by slashes",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"room": schema.StringAttribute{
+				MarkdownDescription: "Name of the room, prefixed with the provider room_name_prefix on the server",
+				Required:            true,
+				Validators: []validator.String{
+					roomName(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"identity": schema.StringAttribute{
+				MarkdownDescription: "Identity of the participant publishing the track",
+				Required:            true,
+				Validators: []validator.String{
+					participantIdentity(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Livekit server url of the room instead of the provider url",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "API key to sign the server API calls with, one of the keys configured on the provider. Defaults to the provider api_key",
+				Optional:            true,
+			},
+			"track_sid": schema.StringAttribute{
+				MarkdownDescription: "Server assigned id of the track. Conflicts with source, set to the track of source otherwise",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Source of the track: camera, microphone, screen_share or screen_share_audio. Conflicts with track_sid",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf("camera", "microphone", "screen_share", "screen_share_audio"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"muted": schema.BoolAttribute{
+				MarkdownDescription: "Whether the track is muted. Defaults to true",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *TrackMuteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*LivekitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *LivekitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TrackMuteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var trackSid, source types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("track_sid"), &trackSid)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source"), &source)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !trackSid.IsNull() && !source.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("source"), "Conflicting attributes",
+			"Only one of track_sid and source can be set.")
+	}
+
+	if trackSid.IsNull() && source.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("source"), "Missing attribute",
+			"One of track_sid and source must be set.")
+	}
+}
+
+// serverClient returns the client for the endpoint of the room.
+func (r *TrackMuteResource) serverClient(data *TrackMuteResourceModel) (*LivekitClient, error) {
+	client, err := r.client.ForEndpoint(data.Endpoint.ValueString(), data.KeyId.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.RequireServer(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// findTrack returns the track of participant that data refers to, by sid or
+// by source, or nil when the participant does not publish it.
+func findTrack(participant *livekit.ParticipantInfo, data *TrackMuteResourceModel) *livekit.TrackInfo {
+	for _, track := range participant.Tracks {
+		if data.Source.IsNull() && track.Sid == data.TrackSid.ValueString() {
+			return track
+		}
+		if !data.Source.IsNull() && strings.ToLower(track.Source.String()) == data.Source.ValueString() {
+			return track
+		}
+	}
+	return nil
+}
+
+func (r *TrackMuteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TrackMuteResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.muteTrack(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	track := data.Source
+	if track.IsNull() {
+		track = data.TrackSid
+	}
+	data.Id = types.StringValue(data.Room.ValueString() + "/" + data.Identity.ValueString() + "/" + track.ValueString())
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// muteTrack looks up the track of data and applies the mute state of data to
+// it.
+func (r *TrackMuteResource) muteTrack(ctx context.Context, data *TrackMuteResourceModel, diags *diag.Diagnostics) {
+	client, err := r.serverClient(data)
+	if err != nil {
+		diags.AddAttributeError(clientErrorPath(err), "Cannot mute track", err.Error())
+		return
+	}
+
+	roomName := client.RoomName(data.Room.ValueString())
+
+	participant, err := findParticipant(ctx, client, roomName, data.Identity.ValueString())
+	if err != nil {
+		diags.AddError("Error reading participant", err.Error())
+		return
+	}
+
+	var track *livekit.TrackInfo
+	if participant != nil {
+		track = findTrack(participant, data)
+	}
+	if track == nil {
+		diags.AddError("Track not found",
+			fmt.Sprintf("Participant %q in room %q does not publish the track.", data.Identity.ValueString(), roomName))
+		return
+	}
+
+	authCtx, err := client.AuthContext(ctx, &auth.VideoGrant{RoomAdmin: true, Room: roomName})
+	if err != nil {
+		diags.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	_, err = client.RoomService.MutePublishedTrack(authCtx, &livekit.MuteRoomTrackRequest{
+		Room:     roomName,
+		Identity: data.Identity.ValueString(),
+		TrackSid: track.Sid,
+		Muted:    data.Muted.ValueBool(),
+	})
+	if err != nil {
+		diags.AddError("Error muting track",
+			fmt.Sprintf("Could not mute track %q of participant %q in room %q: %s", track.Sid, data.Identity.ValueString(), roomName, err))
+		return
+	}
+
+	data.TrackSid = types.StringValue(track.Sid)
+}
+
+func (r *TrackMuteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TrackMuteResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.serverClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(clientErrorPath(err), "Cannot read track", err.Error())
+		return
+	}
+
+	participant, err := findParticipant(ctx, client, client.RoomName(data.Room.ValueString()), data.Identity.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading participant", err.Error())
+		return
+	}
+
+	var track *livekit.TrackInfo
+	if participant != nil {
+		track = findTrack(participant, &data)
+	}
+
+	// The track is muted again once it is published again.
+	if track == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Tracks found by source get a new sid when they are published again.
+	data.TrackSid = types.StringValue(track.Sid)
+	data.Muted = types.BoolValue(track.Muted)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TrackMuteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TrackMuteResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.muteTrack(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TrackMuteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The track is left as it is.
+}
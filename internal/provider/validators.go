@@ -0,0 +1,338 @@
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ validator.String = stringOneOfValidator{}
+var _ validator.List = listElementsOneOfValidator{}
+var _ validator.String = tokenDurationValidator{}
+var _ validator.String = rfc3339TimestampValidator{}
+var _ validator.String = participantIdentityValidator{}
+var _ validator.String = roomNameValidator{}
+var _ validator.Set = roomNameValidator{}
+var _ validator.Map = mapKeysNoneOfValidator{}
+var _ validator.Int64 = positiveInt64Validator{}
+
+// stringOneOfValidator checks that a string is one of a fixed set of values.
+type stringOneOfValidator struct {
+	values []string
+}
+
+func stringOneOf(values ...string) stringOneOfValidator {
+	return stringOneOfValidator{values: values}
+}
+
+func (v stringOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(v.values, req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid value",
+			fmt.Sprintf("Got %q, %s.", req.ConfigValue.ValueString(), v.Description(ctx)))
+	}
+}
+
+// listElementsOneOfValidator checks that every element of a list of strings
+// is one of a fixed set of values.
+type listElementsOneOfValidator struct {
+	values []string
+}
+
+func listElementsOneOf(values ...string) listElementsOneOfValidator {
+	return listElementsOneOfValidator{values: values}
+}
+
+func (v listElementsOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("elements must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v listElementsOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v listElementsOneOfValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for i, element := range req.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		if !slices.Contains(v.values, value.ValueString()) {
+			resp.Diagnostics.AddAttributeError(req.Path.AtListIndex(i), "Invalid value",
+				fmt.Sprintf("Got %q, %s.", value.ValueString(), v.Description(ctx)))
+		}
+	}
+}
+
+// tokenDurationValidator checks that a string parses with parseTokenDuration
+// to a positive duration.
+type tokenDurationValidator struct{}
+
+func tokenDuration() tokenDurationValidator {
+	return tokenDurationValidator{}
+}
+
+func (v tokenDurationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration, e.g. 90m, 12h, 7d, 2w, 6mo or 1y"
+}
+
+func (v tokenDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v tokenDurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := parseTokenDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", err.Error())
+		return
+	}
+
+	if duration <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration",
+			fmt.Sprintf("Got %q, the duration must be longer than zero.", req.ConfigValue.ValueString()))
+	}
+}
+
+// positiveInt64Validator checks that a number is greater than zero.
+type positiveInt64Validator struct{}
+
+func positiveInt64() positiveInt64Validator {
+	return positiveInt64Validator{}
+}
+
+func (v positiveInt64Validator) Description(ctx context.Context) string {
+	return "value must be greater than zero"
+}
+
+func (v positiveInt64Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v positiveInt64Validator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if req.ConfigValue.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid value",
+			fmt.Sprintf("Got %d, %s.", req.ConfigValue.ValueInt64(), v.Description(ctx)))
+	}
+}
+
+// rfc3339TimestampValidator checks that a string is an RFC3339 timestamp.
+type rfc3339TimestampValidator struct{}
+
+func rfc3339Timestamp() rfc3339TimestampValidator {
+	return rfc3339TimestampValidator{}
+}
+
+func (v rfc3339TimestampValidator) Description(ctx context.Context) string {
+	return "value must be an RFC3339 timestamp, e.g. 2026-03-01T18:00:00Z"
+}
+
+func (v rfc3339TimestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339TimestampValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid timestamp",
+			fmt.Sprintf("Got %q, %s.", req.ConfigValue.ValueString(), v.Description(ctx)))
+	}
+}
+
+// maxIdentityLength is the longest participant identity accepted, in
+// characters.
+const maxIdentityLength = 256
+
+// participantIdentityValidator checks that a string, each string of a set or
+// each key of a map is usable as participant identity: not empty, not too
+// long and without control characters.
+type participantIdentityValidator struct{}
+
+func participantIdentity() participantIdentityValidator {
+	return participantIdentityValidator{}
+}
+
+func (v participantIdentityValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a non-empty identity of at most %d characters without control characters", maxIdentityLength)
+}
+
+func (v participantIdentityValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v participantIdentityValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateIdentity(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid identity", err.Error())
+	}
+}
+
+func (v participantIdentityValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, element := range req.ConfigValue.Elements() {
+		identity, ok := element.(types.String)
+		if !ok || identity.IsNull() || identity.IsUnknown() {
+			continue
+		}
+
+		if err := validateIdentity(identity.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtSetValue(identity), "Invalid identity", err.Error())
+		}
+	}
+}
+
+func (v participantIdentityValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for identity := range req.ConfigValue.Elements() {
+		if err := validateIdentity(identity); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtMapKey(identity), "Invalid identity", err.Error())
+		}
+	}
+}
+
+// validateIdentity checks the constraints of participantIdentityValidator.
+func validateIdentity(identity string) error {
+	switch {
+	case strings.TrimSpace(identity) == "":
+		return fmt.Errorf("identity must not be empty")
+	case utf8.RuneCountInString(identity) > maxIdentityLength:
+		return fmt.Errorf("identity is %d characters long, at most %d are allowed", utf8.RuneCountInString(identity), maxIdentityLength)
+	case strings.IndexFunc(identity, unicode.IsControl) >= 0:
+		return fmt.Errorf("identity %q contains control characters", identity)
+	}
+	return nil
+}
+
+// maxRoomNameLength is the longest room name accepted, in characters.
+const maxRoomNameLength = 256
+
+// roomNameValidator checks that a string, or each string of a set, is usable
+// as room name: not empty, not too long and without control characters. The
+// provider room_name_prefix is not taken into account.
+type roomNameValidator struct{}
+
+func roomName() roomNameValidator {
+	return roomNameValidator{}
+}
+
+func (v roomNameValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a non-empty room name of at most %d characters without control characters", maxRoomNameLength)
+}
+
+func (v roomNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v roomNameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateRoomName(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid room name", err.Error())
+	}
+}
+
+func (v roomNameValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, element := range req.ConfigValue.Elements() {
+		name, ok := element.(types.String)
+		if !ok || name.IsNull() || name.IsUnknown() {
+			continue
+		}
+
+		if err := validateRoomName(name.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtSetValue(name), "Invalid room name", err.Error())
+		}
+	}
+}
+
+// validateRoomName checks the constraints of roomNameValidator.
+func validateRoomName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("room name must not be empty")
+	case utf8.RuneCountInString(name) > maxRoomNameLength:
+		return fmt.Errorf("room name is %d characters long, at most %d are allowed", utf8.RuneCountInString(name), maxRoomNameLength)
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		return fmt.Errorf("room name %q contains control characters", name)
+	}
+	return nil
+}
+
+// mapKeysNoneOfValidator checks that a map uses none of a fixed set of keys.
+type mapKeysNoneOfValidator struct {
+	keys []string
+}
+
+func mapKeysNoneOf(keys ...string) mapKeysNoneOfValidator {
+	return mapKeysNoneOfValidator{keys: keys}
+}
+
+func (v mapKeysNoneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("keys must not be any of: %s", strings.Join(v.keys, ", "))
+}
+
+func (v mapKeysNoneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v mapKeysNoneOfValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for key := range req.ConfigValue.Elements() {
+		if slices.Contains(v.keys, key) {
+			resp.Diagnostics.AddAttributeError(req.Path.AtMapKey(key), "Invalid key",
+				fmt.Sprintf("Got %q, %s.", key, v.Description(ctx)))
+		}
+	}
+}